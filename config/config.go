@@ -6,6 +6,8 @@ package config
 import (
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -15,6 +17,49 @@ type Config struct {
 	ServerPort  string
 	WorkerCount int
 	BatchSize   int
+
+	// UpsertStrategy selects how product batches are written: "gorm" for
+	// multi-row INSERTs or "copy" for COPY into a staging table.
+	UpsertStrategy string
+
+	// ColumnAliases extends the default CSV header aliases, in the form
+	// "Index:id,product_id:id,Internal ID:internal_id".
+	ColumnAliases string
+
+	// JobQueueSize bounds the number of import jobs waiting to run and
+	// JobRunners is how many jobs run concurrently.
+	JobQueueSize int
+	JobRunners   int
+
+	// JobTimeout cancels an import job that runs longer, zero disables it
+	JobTimeout time.Duration
+
+	// ImportDir is the only directory imports may read from; request paths
+	// are relative to it.
+	ImportDir string
+
+	// UploadDir keeps files uploaded for import, relative to ImportDir,
+	// and UploadMaxSize limits each file in bytes. UploadMaxFiles and
+	// UploadMaxRequestSize limit the files and bytes of one request.
+	UploadDir            string
+	UploadMaxSize        int64
+	UploadMaxFiles       int
+	UploadMaxRequestSize int64
+
+	// WatchDir is an inbox, relative to ImportDir, whose files are imported
+	// with WatchProfile once unchanged for WatchSettle. Empty disables it.
+	WatchDir     string
+	WatchProfile string
+	WatchSettle  time.Duration
+
+	// BaseCurrency is the ISO 4217 currency prices are converted into by
+	// profiles with currency conversion. Empty disables conversion.
+	BaseCurrency string
+
+	// WSAllowedOrigins lists the origins besides the API's own, such as
+	// the admin UI, that may open job WebSockets, in the form
+	// "https://admin.example.com,http://localhost:3000".
+	WSAllowedOrigins []string
 }
 
 func LoadConfig() *Config {
@@ -25,7 +70,7 @@ func LoadConfig() *Config {
 	viper.AutomaticEnv()
 
 	if err := viper.ReadInConfig(); err != nil {
-		log.Fatalf("failed to load config file", err)
+		log.Fatalf("failed to load config file: %v", err)
 	}
 
 	return &Config{
@@ -33,6 +78,23 @@ func LoadConfig() *Config {
 		ServerPort:  getRequiredString("SERVER_PORT"),
 		WorkerCount: getRequiredInt("WORKER_COUNT"),
 		BatchSize:   getRequiredInt("BATCH_SIZE"),
+
+		UpsertStrategy: getOptionalString("UPSERT_STRATEGY", "gorm"),
+		ColumnAliases:  getOptionalString("COLUMN_ALIASES", ""),
+		JobQueueSize:   getOptionalInt("JOB_QUEUE_SIZE", 100),
+		JobRunners:     getOptionalInt("JOB_RUNNERS", 1),
+		JobTimeout:     getOptionalDuration("JOB_TIMEOUT", 0),
+		ImportDir:      getOptionalString("IMPORT_DIR", "."),
+		UploadDir:      getOptionalString("UPLOAD_DIR", "uploads"),
+		UploadMaxSize:  int64(getOptionalInt("UPLOAD_MAX_SIZE", 100<<20)),
+		UploadMaxFiles: getOptionalInt("UPLOAD_MAX_FILES", 20),
+		WatchDir:       getOptionalString("WATCH_DIR", ""),
+		WatchProfile:   getOptionalString("WATCH_PROFILE", ""),
+		WatchSettle:    getOptionalDuration("WATCH_SETTLE", 5*time.Second),
+		BaseCurrency:   getOptionalString("BASE_CURRENCY", ""),
+
+		UploadMaxRequestSize: int64(getOptionalInt("UPLOAD_MAX_REQUEST_SIZE", 1<<30)),
+		WSAllowedOrigins:     getOptionalList("WS_ALLOWED_ORIGINS"),
 	}
 }
 
@@ -52,3 +114,38 @@ func getRequiredInt(key string) int {
 
 	panic(fmt.Errorf("KEY %s IS MISSING", key))
 }
+
+func getOptionalString(key string, defaultValue string) string {
+	if viper.IsSet(key) {
+		return viper.GetString(key)
+	}
+
+	return defaultValue
+}
+
+// getOptionalList splits a comma-separated value, dropping empty entries.
+func getOptionalList(key string) []string {
+	var list []string
+	for _, value := range strings.Split(getOptionalString(key, ""), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+func getOptionalInt(key string, defaultValue int) int {
+	if viper.IsSet(key) {
+		return viper.GetInt(key)
+	}
+
+	return defaultValue
+}
+
+func getOptionalDuration(key string, defaultValue time.Duration) time.Duration {
+	if viper.IsSet(key) {
+		return viper.GetDuration(key)
+	}
+
+	return defaultValue
+}
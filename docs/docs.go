@@ -17,7 +17,7 @@ const docTemplate = `{
     "paths": {
         "/csv/process": {
             "post": {
-                "description": "Insert / Update Process CSV",
+                "description": "Queue an asynchronous Insert / Update Process CSV job",
                 "consumes": [
                     "application/json"
                 ],
@@ -39,6 +39,370 @@ const docTemplate = `{
                         }
                     }
                 ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/csv/upload": {
+            "post": {
+                "description": "Upload one or more files and queue an import job for them",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "csv"
+                ],
+                "summary": "Upload CSV",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Files to import, repeat the field for several files",
+                        "name": "files",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Import profile name",
+                        "name": "profile",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field delimiter",
+                        "name": "delimiter",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quote character",
+                        "name": "quote",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment prefix",
+                        "name": "comment",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Accept stray quotes",
+                        "name": "lazy_quotes",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Character encoding, or auto",
+                        "name": "encoding",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Abort a file on its first failed row",
+                        "name": "fail_fast",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Abort a file once this many rows failed",
+                        "name": "max_failures",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Abort a file once this percentage of rows failed",
+                        "name": "max_failure_rate",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Roll back the writes of an aborted file",
+                        "name": "rollback_aborted",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Commit the rows of each file, or of every file, together (file, request)",
+                        "name": "atomic",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange-rates": {
+            "get": {
+                "description": "List all exchange rates",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exchange-rates"
+                ],
+                "summary": "List Exchange Rates",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create or replace the rate converting a currency into a base currency",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exchange-rates"
+                ],
+                "summary": "Save Exchange Rate",
+                "parameters": [
+                    {
+                        "description": "Exchange Rate",
+                        "name": "rate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ExchangeRateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs": {
+            "get": {
+                "description": "List import jobs, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "List Import Jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by status (queued, running, succeeded, failed, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of jobs (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "description": "Get the status and result of an import job",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Get Import Job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/progress": {
+            "get": {
+                "description": "Server-Sent Events stream of \"progress\" events followed by a final \"summary\" event",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Stream Import Job Progress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ProgressUpdate"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/profiles": {
+            "get": {
+                "description": "List all import profiles",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profiles"
+                ],
+                "summary": "List Import Profiles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create or replace a named import profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profiles"
+                ],
+                "summary": "Save Import Profile",
+                "parameters": [
+                    {
+                        "description": "Import Profile",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ImportProfileRequest"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -56,20 +420,309 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/profiles/{name}": {
+            "get": {
+                "description": "Get an import profile by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profiles"
+                ],
+                "summary": "Get Import Profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Profile Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/ws/jobs/{id}": {
+            "get": {
+                "description": "Pushes job events and accepts {\"action\": \"pause\" | \"resume\" | \"cancel\"} control messages",
+                "tags": [
+                    "jobs"
+                ],
+                "summary": "Import Job WebSocket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "$ref": "#/definitions/handler.JobSocketMessage"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
+        "domain.ProgressUpdate": {
+            "type": "object",
+            "properties": {
+                "bytesRead": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "fileName": {
+                    "type": "string"
+                },
+                "inserted": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "number",
+                    "format": "float64"
+                },
+                "processedCount": {
+                    "type": "integer"
+                },
+                "totalBytes": {
+                    "type": "integer",
+                    "format": "int64"
+                },
+                "totalRecords": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.ExchangeRateRequest": {
+            "type": "object",
+            "required": [
+                "base_currency",
+                "currency",
+                "rate"
+            ],
+            "properties": {
+                "base_currency": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                }
+            }
+        },
+        "handler.FieldRuleRequest": {
+            "type": "object",
+            "properties": {
+                "allowed": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "max": {
+                    "type": "number"
+                },
+                "max_length": {
+                    "type": "integer"
+                },
+                "min": {
+                    "type": "number"
+                },
+                "pattern": {
+                    "type": "string"
+                },
+                "required": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handler.ImportProfileRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "availability_stock": {
+                    "description": "AvailabilityStock is off, derive, flag or reject",
+                    "type": "string"
+                },
+                "availability_synonyms": {
+                    "description": "AvailabilitySynonyms maps feed values to availabilities, e.g.\n\"Lieferbar\": \"in_stock\"",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "column_mapping": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "convert_currency": {
+                    "description": "ConvertCurrency converts prices into the base currency of the catalog",
+                    "type": "boolean"
+                },
+                "decimal_separator": {
+                    "type": "string"
+                },
+                "delimiter": {
+                    "type": "string"
+                },
+                "ean_format": {
+                    "description": "EanFormat is ean13 or gtin14",
+                    "type": "string"
+                },
+                "ean_strictness": {
+                    "description": "EanStrictness is off, flag or reject",
+                    "type": "string"
+                },
+                "encoding": {
+                    "type": "string"
+                },
+                "header_row": {
+                    "description": "HeaderRow is the 1-based row holding the header",
+                    "type": "integer"
+                },
+                "lazy_quotes": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quote": {
+                    "type": "string"
+                },
+                "rules": {
+                    "description": "Rules add validation rules to product fields, keyed by field name",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/handler.FieldRuleRequest"
+                    }
+                },
+                "sheet": {
+                    "description": "Sheet selects an XLSX sheet by name or 1-based position",
+                    "type": "string"
+                },
+                "transforms": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "handler.JobSocketMessage": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "data": {},
+                "error": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
         "handler.ProcessCSVRequest": {
             "type": "object",
             "required": [
                 "file_paths"
             ],
             "properties": {
+                "atomic": {
+                    "description": "Atomic commits the rows of each file, or of the whole request,\ntogether: file or request",
+                    "type": "string"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "delimiter": {
+                    "description": "Delimiter, Quote, Comment, LazyQuotes and Encoding override the\nprofile's CSV settings for this import; LazyQuotes false turns off\nlazy quotes enabled by the profile",
+                    "type": "string"
+                },
+                "encoding": {
+                    "type": "string"
+                },
+                "fail_fast": {
+                    "description": "FailFast, MaxFailures and MaxFailureRate abort a file once its\nfailed rows reach the limit; RollbackAborted undoes its writes",
+                    "type": "boolean"
+                },
                 "file_paths": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
+                },
+                "lazy_quotes": {
+                    "type": "boolean"
+                },
+                "max_failure_rate": {
+                    "type": "number"
+                },
+                "max_failures": {
+                    "type": "integer"
+                },
+                "profile": {
+                    "type": "string"
+                },
+                "quote": {
+                    "type": "string"
+                },
+                "recursive": {
+                    "type": "boolean"
+                },
+                "rollback_aborted": {
+                    "type": "boolean"
                 }
             }
         }
@@ -4,11 +4,19 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"strconv"
+	"time"
 
 	"data-processing/internal/domain"
+	"data-processing/pkg/csv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 
 	_ "data-processing/docs"
 
@@ -17,11 +25,37 @@ import (
 )
 
 type Handler struct {
-	usecase domain.CSVProcessorUsecase
+	jobUsecase     domain.ImportJobUsecase
+	profileUsecase domain.ImportProfileUsecase
+	rateUsecase    domain.ExchangeRateUsecase
+	fileStore      domain.FileStore
+	upgrader       *websocket.Upgrader
+	// maxUploadFiles and maxUploadBytes limit a single upload request
+	maxUploadFiles int
+	maxUploadBytes int64
 }
 
-func NewHandler(usecase domain.CSVProcessorUsecase) *Handler {
-	return &Handler{usecase: usecase}
+// NewHandler creates the API handlers. An upload request may carry at most
+// maxUploadFiles files and maxUploadBytes bytes, and allowedOrigins lists
+// the origins besides the API's own that may open job WebSockets.
+func NewHandler(
+	jobUsecase domain.ImportJobUsecase,
+	profileUsecase domain.ImportProfileUsecase,
+	rateUsecase domain.ExchangeRateUsecase,
+	fileStore domain.FileStore,
+	maxUploadFiles int,
+	maxUploadBytes int64,
+	allowedOrigins []string,
+) *Handler {
+	return &Handler{
+		jobUsecase:     jobUsecase,
+		profileUsecase: profileUsecase,
+		rateUsecase:    rateUsecase,
+		fileStore:      fileStore,
+		upgrader:       newUpgrader(allowedOrigins),
+		maxUploadFiles: maxUploadFiles,
+		maxUploadBytes: maxUploadBytes,
+	}
 }
 
 func (h *Handler) RegisterRoutes(r *gin.Engine) {
@@ -29,23 +63,106 @@ func (h *Handler) RegisterRoutes(r *gin.Engine) {
 	api := r.Group("/api/v1")
 	{
 		api.POST("/csv/process", h.ProcessCSV)
+		api.POST("/csv/upload", h.UploadCSV)
+
+		api.GET("/jobs", h.ListJobs)
+		api.GET("/jobs/:id", h.GetJob)
+		api.GET("/jobs/:id/progress", h.StreamJobProgress)
+		api.GET("/ws/jobs/:id", h.JobWebSocket)
+
+		api.POST("/profiles", h.SaveProfile)
+		api.GET("/profiles", h.ListProfiles)
+		api.GET("/profiles/:name", h.GetProfile)
+
+		api.POST("/exchange-rates", h.SaveExchangeRate)
+		api.GET("/exchange-rates", h.ListExchangeRates)
 	}
 }
 
+// ProcessCSVRequest names the files to import. Entries may be files,
+// directories or glob patterns such as "/csv/*.csv", relative to the
+// import directory.
 type ProcessCSVRequest struct {
 	FilePaths []string `json:"file_paths" binding:"required"`
+	Profile   string   `json:"profile"`
+	Recursive bool     `json:"recursive"`
+	// Delimiter, Quote, Comment, LazyQuotes and Encoding override the
+	// profile's CSV settings for this import; LazyQuotes false turns off
+	// lazy quotes enabled by the profile
+	Delimiter  string `json:"delimiter"`
+	Quote      string `json:"quote"`
+	Comment    string `json:"comment"`
+	LazyQuotes *bool  `json:"lazy_quotes"`
+	Encoding   string `json:"encoding"`
+	// FailFast, MaxFailures and MaxFailureRate abort a file once its
+	// failed rows reach the limit; RollbackAborted undoes its writes
+	FailFast        bool    `json:"fail_fast"`
+	MaxFailures     int     `json:"max_failures"`
+	MaxFailureRate  float64 `json:"max_failure_rate"`
+	RollbackAborted bool    `json:"rollback_aborted"`
+	// Atomic commits the rows of each file, or of the whole request,
+	// together: file or request
+	Atomic string `json:"atomic"`
+}
+
+type ImportProfileRequest struct {
+	Name             string              `json:"name" binding:"required"`
+	ColumnMapping    map[string]string   `json:"column_mapping"`
+	Delimiter        string              `json:"delimiter"`
+	Quote            string              `json:"quote"`
+	Comment          string              `json:"comment"`
+	LazyQuotes       bool                `json:"lazy_quotes"`
+	Encoding         string              `json:"encoding"`
+	DecimalSeparator string              `json:"decimal_separator"`
+	Transforms       map[string][]string `json:"transforms"`
+	// Rules add validation rules to product fields, keyed by field name
+	Rules map[string]FieldRuleRequest `json:"rules"`
+	// EanStrictness is off, flag or reject
+	EanStrictness string `json:"ean_strictness"`
+	// EanFormat is ean13 or gtin14
+	EanFormat string `json:"ean_format"`
+	// ConvertCurrency converts prices into the base currency of the catalog
+	ConvertCurrency bool `json:"convert_currency"`
+	// AvailabilitySynonyms maps feed values to availabilities, e.g.
+	// "Lieferbar": "in_stock"
+	AvailabilitySynonyms map[string]string `json:"availability_synonyms"`
+	// AvailabilityStock is off, derive, flag or reject
+	AvailabilityStock string `json:"availability_stock"`
+	// Sheet selects an XLSX sheet by name or 1-based position
+	Sheet string `json:"sheet"`
+	// HeaderRow is the 1-based row holding the header
+	HeaderRow int `json:"header_row"`
+}
+
+// FieldRuleRequest declares the checks applied to one product field.
+type FieldRuleRequest struct {
+	Required  bool     `json:"required"`
+	Min       *float64 `json:"min"`
+	Max       *float64 `json:"max"`
+	MaxLength int      `json:"max_length"`
+	Pattern   string   `json:"pattern"`
+	Allowed   []string `json:"allowed"`
+}
+
+// ExchangeRateRequest sets the value of one unit of Currency in
+// BaseCurrency.
+type ExchangeRateRequest struct {
+	Currency     string  `json:"currency" binding:"required"`
+	BaseCurrency string  `json:"base_currency" binding:"required"`
+	Rate         float64 `json:"rate" binding:"required"`
 }
 
 // @BasePath /api/v1
 
 // @Summary Process CSV
-// @Description Insert / Update Process CSV
+// @Description Queue an asynchronous Insert / Update Process CSV job
 // @Tags csv
 // @Accept json
 // @Produce json
 // @Param csv body ProcessCSVRequest true "Array Path CSV"
-// @Success 200 {object} map[string]interface{}
+// @Success 202 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
+// @Failure 503 {object} map[string]interface{}
 // @Router /csv/process [post]
 func (h *Handler) ProcessCSV(c *gin.Context) {
 	var req ProcessCSVRequest
@@ -54,28 +171,515 @@ func (h *Handler) ProcessCSV(c *gin.Context) {
 		return
 	}
 
-	// Channel for progress updates (optional for WebSocket in future)
-	progressChan := make(chan *domain.ProgressUpdate, 100)
+	job, err := h.jobUsecase.Enqueue(req.FilePaths, domain.ImportOptions{
+		Profile:   req.Profile,
+		Recursive: req.Recursive,
+		Dialect: domain.CSVDialect{
+			Delimiter:  req.Delimiter,
+			Quote:      req.Quote,
+			Comment:    req.Comment,
+			LazyQuotes: req.LazyQuotes,
+			Encoding:   req.Encoding,
+		},
+		ErrorPolicy: domain.ErrorPolicy{
+			FailFast:       req.FailFast,
+			MaxFailures:    req.MaxFailures,
+			MaxFailureRate: req.MaxFailureRate,
+			Rollback:       req.RollbackAborted,
+		},
+		Atomic: domain.AtomicScope(req.Atomic),
+	})
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "CSV import job queued",
+		"job_id":  job.ID,
+		"result":  job,
+	})
+}
+
+// @Summary Upload CSV
+// @Description Upload one or more files and queue an import job for them
+// @Tags csv
+// @Accept multipart/form-data
+// @Produce json
+// @Param files formData file true "Files to import, repeat the field for several files"
+// @Param profile formData string false "Import profile name"
+// @Param delimiter formData string false "Field delimiter"
+// @Param quote formData string false "Quote character"
+// @Param comment formData string false "Comment prefix"
+// @Param lazy_quotes formData boolean false "Accept stray quotes"
+// @Param encoding formData string false "Character encoding, or auto"
+// @Param fail_fast formData boolean false "Abort a file on its first failed row"
+// @Param max_failures formData int false "Abort a file once this many rows failed"
+// @Param max_failure_rate formData number false "Abort a file once this percentage of rows failed"
+// @Param rollback_aborted formData boolean false "Roll back the writes of an aborted file"
+// @Param atomic formData string false "Commit the rows of each file, or of every file, together (file, request)"
+// @Success 202 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 413 {object} map[string]interface{}
+// @Failure 503 {object} map[string]interface{}
+// @Router /csv/upload [post]
+func (h *Handler) UploadCSV(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadBytes)
+
+	// Parts are streamed straight to the store rather than buffered by
+	// ParseMultipartForm
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var files []*domain.StoredFile
+	var options domain.ImportOptions
+	fail := func(status int, err error) {
+		h.removeUploads(files)
+		c.JSON(status, gin.H{"error": err.Error()})
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			fail(http.StatusBadRequest, err)
+			return
+		}
+
+		switch {
+		case part.FileName() != "":
+			if len(files) == h.maxUploadFiles {
+				part.Close()
+				fail(http.StatusRequestEntityTooLarge, fmt.Errorf("%w: at most %d per upload", domain.ErrTooManyFiles, h.maxUploadFiles))
+				return
+			}
+			file, err := h.fileStore.Save(uploadName(part), part)
+			part.Close()
+			if err != nil {
+				fail(errorStatus(err), err)
+				return
+			}
+			files = append(files, file)
+		case formOptions[part.FormName()]:
+			// Read one byte past the limit to reject longer values rather
+			// than truncate them
+			value, err := io.ReadAll(io.LimitReader(part, maxFormValue+1))
+			part.Close()
+			if err == nil && len(value) > maxFormValue {
+				err = fmt.Errorf("%s is longer than %d bytes", part.FormName(), maxFormValue)
+			}
+			if err == nil {
+				err = setFormOption(&options, part.FormName(), string(value))
+			}
+			if err != nil {
+				fail(http.StatusBadRequest, err)
+				return
+			}
+		default:
+			part.Close()
+		}
+	}
+
+	if len(files) == 0 {
+		fail(http.StatusBadRequest, errors.New("no files uploaded"))
+		return
+	}
+
+	filePaths := make([]string, 0, len(files))
+	for _, file := range files {
+		filePaths = append(filePaths, file.Path)
+	}
+
+	job, err := h.jobUsecase.Enqueue(filePaths, options)
+	if err != nil {
+		fail(errorStatus(err), err)
+		return
+	}
+	go h.removeWhenFinished(job.ID, files)
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "CSV import job queued",
+		"job_id":  job.ID,
+		"files":   files,
+		"result":  job,
+	})
+}
+
+// maxFormValue is the longest upload form field value accepted.
+const maxFormValue = 256
+
+// formOptions lists the upload form fields that set import options.
+var formOptions = map[string]bool{
+	"profile":          true,
+	"delimiter":        true,
+	"quote":            true,
+	"comment":          true,
+	"lazy_quotes":      true,
+	"encoding":         true,
+	"fail_fast":        true,
+	"max_failures":     true,
+	"max_failure_rate": true,
+	"rollback_aborted": true,
+	"atomic":           true,
+}
+
+// setFormOption applies one upload form field to options.
+func setFormOption(options *domain.ImportOptions, name, value string) error {
+	switch name {
+	case "profile":
+		options.Profile = value
+	case "delimiter":
+		options.Dialect.Delimiter = value
+	case "quote":
+		options.Dialect.Quote = value
+	case "comment":
+		options.Dialect.Comment = value
+	case "lazy_quotes":
+		lazyQuotes, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid lazy_quotes %q", value)
+		}
+		options.Dialect.LazyQuotes = &lazyQuotes
+	case "encoding":
+		options.Dialect.Encoding = value
+	case "fail_fast":
+		failFast, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid fail_fast %q", value)
+		}
+		options.ErrorPolicy.FailFast = failFast
+	case "max_failures":
+		maxFailures, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid max_failures %q", value)
+		}
+		options.ErrorPolicy.MaxFailures = maxFailures
+	case "max_failure_rate":
+		maxFailureRate, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid max_failure_rate %q", value)
+		}
+		options.ErrorPolicy.MaxFailureRate = maxFailureRate
+	case "rollback_aborted":
+		rollback, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid rollback_aborted %q", value)
+		}
+		options.ErrorPolicy.Rollback = rollback
+	case "atomic":
+		options.Atomic = domain.AtomicScope(value)
+	}
+	return nil
+}
+
+// uploadName returns the file name of an uploaded part, adding the
+// extension of its content type when the name does not tell the format.
+func uploadName(part *multipart.Part) string {
+	name := part.FileName()
+	if csv.DetectFormat(name) != "" {
+		return name
+	}
+	if format := csv.FormatForContentType(part.Header.Get("Content-Type")); format != "" {
+		return name + format.Extension()
+	}
+	return name
+}
+
+// removeUploads deletes files stored for a request that was rejected.
+func (h *Handler) removeUploads(files []*domain.StoredFile) {
+	for _, file := range files {
+		h.fileStore.Remove(file)
+	}
+}
+
+// removeWhenFinished deletes the uploaded files once their job reaches a
+// terminal status, as nothing reads them afterwards. Files whose job cannot
+// be followed are kept.
+func (h *Handler) removeWhenFinished(jobID string, files []*domain.StoredFile) {
+	events, unsubscribe, err := h.jobUsecase.Subscribe(jobID)
+	if err != nil {
+		return
+	}
+	defer unsubscribe()
+
+	for event := range events {
+		if event.Type == domain.JobEventSummary {
+			h.removeUploads(files)
+			return
+		}
+	}
+}
+
+// @Summary List Import Jobs
+// @Description List import jobs, newest first
+// @Tags jobs
+// @Produce json
+// @Param status query string false "Filter by status (queued, running, succeeded, failed, cancelled)"
+// @Param limit query int false "Maximum number of jobs (default 50, max 500)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /jobs [get]
+func (h *Handler) ListJobs(c *gin.Context) {
+	limit := 50
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > 500 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 500"})
+			return
+		}
+		limit = parsed
+	}
+
+	jobs, err := h.jobUsecase.ListJobs(domain.JobStatus(c.Query("status")), limit)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": jobs})
+}
+
+// @Summary Get Import Job
+// @Description Get the status and result of an import job
+// @Tags jobs
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /jobs/{id} [get]
+func (h *Handler) GetJob(c *gin.Context) {
+	job, err := h.jobUsecase.GetJob(c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": job})
+}
+
+// sseHeartbeat keeps idle progress streams open behind proxies.
+const sseHeartbeat = 15 * time.Second
+
+// @Summary Stream Import Job Progress
+// @Description Server-Sent Events stream of "progress" events followed by a final "summary" event
+// @Tags jobs
+// @Produce text/event-stream
+// @Param id path string true "Job ID"
+// @Success 200 {object} domain.ProgressUpdate
+// @Failure 404 {object} map[string]interface{}
+// @Router /jobs/{id}/progress [get]
+func (h *Handler) StreamJobProgress(c *gin.Context) {
+	events, unsubscribe, err := h.jobUsecase.Subscribe(c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
 
-	// Close progress channel when done
-	defer close(progressChan)
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
 
-	// Process in background and collect progress
-	go func() {
-		for progress := range progressChan {
-			// In production, send via WebSocket or SSE
-			_ = progress
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(event.Type, eventData(event))
+			return true
+		case <-heartbeat.C:
+			c.SSEvent("ping", time.Now().Unix())
+			return true
+		case <-c.Request.Context().Done():
+			return false
 		}
-	}()
+	})
+}
+
+// @Summary Save Import Profile
+// @Description Create or replace a named import profile
+// @Tags profiles
+// @Accept json
+// @Produce json
+// @Param profile body ImportProfileRequest true "Import Profile"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /profiles [post]
+func (h *Handler) SaveProfile(c *gin.Context) {
+	var req ImportProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile := &domain.ImportProfile{
+		Name:                 req.Name,
+		ColumnMapping:        req.ColumnMapping,
+		Delimiter:            req.Delimiter,
+		Quote:                req.Quote,
+		Comment:              req.Comment,
+		LazyQuotes:           req.LazyQuotes,
+		Encoding:             req.Encoding,
+		DecimalSeparator:     req.DecimalSeparator,
+		Transforms:           req.Transforms,
+		Rules:                validationRules(req.Rules),
+		EanStrictness:        req.EanStrictness,
+		EanFormat:            req.EanFormat,
+		ConvertCurrency:      req.ConvertCurrency,
+		AvailabilitySynonyms: req.AvailabilitySynonyms,
+		AvailabilityStock:    req.AvailabilityStock,
+		Sheet:                req.Sheet,
+		HeaderRow:            req.HeaderRow,
+	}
+	if err := h.profileUsecase.SaveProfile(profile); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Import profile saved successfully",
+		"result":  profile,
+	})
+}
+
+// @Summary List Import Profiles
+// @Description List all import profiles
+// @Tags profiles
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /profiles [get]
+func (h *Handler) ListProfiles(c *gin.Context) {
+	profiles, err := h.profileUsecase.ListProfiles()
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": profiles})
+}
 
-	result, err := h.usecase.ProcessCSVFiles(req.FilePaths, progressChan)
+// @Summary Get Import Profile
+// @Description Get an import profile by name
+// @Tags profiles
+// @Produce json
+// @Param name path string true "Profile Name"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /profiles/{name} [get]
+func (h *Handler) GetProfile(c *gin.Context) {
+	profile, err := h.profileUsecase.GetProfile(c.Param("name"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": profile})
+}
+
+// @Summary Save Exchange Rate
+// @Description Create or replace the rate converting a currency into a base currency
+// @Tags exchange-rates
+// @Accept json
+// @Produce json
+// @Param rate body ExchangeRateRequest true "Exchange Rate"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Router /exchange-rates [post]
+func (h *Handler) SaveExchangeRate(c *gin.Context) {
+	var req ExchangeRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rate := &domain.ExchangeRate{
+		Currency:     req.Currency,
+		BaseCurrency: req.BaseCurrency,
+		Rate:         req.Rate,
+	}
+	if err := h.rateUsecase.SaveRate(rate); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "CSV files processed successfully",
-		"result":  result,
+		"message": "Exchange rate saved successfully",
+		"result":  rate,
 	})
 }
+
+// @Summary List Exchange Rates
+// @Description List all exchange rates
+// @Tags exchange-rates
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /exchange-rates [get]
+func (h *Handler) ListExchangeRates(c *gin.Context) {
+	rates, err := h.rateUsecase.ListRates()
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": rates})
+}
+
+// validationRules converts the rules of a profile request.
+func validationRules(rules map[string]FieldRuleRequest) domain.ValidationRules {
+	if rules == nil {
+		return nil
+	}
+
+	converted := make(domain.ValidationRules, len(rules))
+	for field, rule := range rules {
+		converted[field] = domain.FieldRule{
+			Required:  rule.Required,
+			Min:       rule.Min,
+			Max:       rule.Max,
+			MaxLength: rule.MaxLength,
+			Pattern:   rule.Pattern,
+			Allowed:   rule.Allowed,
+		}
+	}
+	return converted
+}
+
+// errorStatus maps domain errors to HTTP status codes.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrProfileNotFound),
+		errors.Is(err, domain.ErrJobNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrJobNotActive):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrInvalidJobAction):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrQueueFull),
+		errors.Is(err, domain.ErrQueueClosed):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, domain.ErrInvalidProfile),
+		errors.Is(err, domain.ErrInvalidDialect),
+		errors.Is(err, domain.ErrInvalidErrorPolicy),
+		errors.Is(err, domain.ErrInvalidAtomicScope),
+		errors.Is(err, domain.ErrInvalidExchangeRate),
+		errors.Is(err, domain.ErrPathNotAllowed),
+		errors.Is(err, domain.ErrNoMatchingFiles):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrFileTooLarge),
+		errors.Is(err, domain.ErrTooManyFiles),
+		errors.As(err, new(*http.MaxBytesError)):
+		return http.StatusRequestEntityTooLarge
+	default:
+		return http.StatusInternalServerError
+	}
+}
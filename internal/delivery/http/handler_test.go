@@ -0,0 +1,216 @@
+// ============================================
+// internal/delivery/http/handler_test.go
+// ============================================
+package handler
+
+import (
+	"bytes"
+	"data-processing/internal/domain"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFileStore keeps uploads in memory and records removed files.
+type fakeFileStore struct {
+	mu      sync.Mutex
+	saved   []*domain.StoredFile
+	removed []*domain.StoredFile
+}
+
+func (s *fakeFileStore) Save(name string, r io.Reader) (*domain.StoredFile, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file := &domain.StoredFile{
+		Name: name,
+		Path: fmt.Sprintf("/uploads/%d/%s", len(s.saved), name),
+		Size: int64(len(data)),
+	}
+	s.saved = append(s.saved, file)
+	return file, nil
+}
+
+func (s *fakeFileStore) Remove(file *domain.StoredFile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removed = append(s.removed, file)
+	return nil
+}
+
+func (s *fakeFileStore) removedFiles() []*domain.StoredFile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*domain.StoredFile(nil), s.removed...)
+}
+
+// uploadPart is one part of a multipart upload request.
+type uploadPart struct {
+	field    string
+	fileName string
+	content  string
+}
+
+func newUploadRequest(t *testing.T, parts ...uploadPart) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, part := range parts {
+		var w io.Writer
+		var err error
+		if part.fileName != "" {
+			w, err = writer.CreateFormFile(part.field, part.fileName)
+		} else {
+			w, err = writer.CreateFormField(part.field)
+		}
+		require.NoError(t, err)
+		_, err = io.WriteString(w, part.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/csv/upload", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func serve(h *Handler, req *http.Request) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	h.RegisterRoutes(router)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_UploadCSV(t *testing.T) {
+	t.Run("success - files queued", func(t *testing.T) {
+		jobUsecase := domain.NewMockImportJobUsecase(t)
+		store := &fakeFileStore{}
+		h := NewHandler(jobUsecase, nil, nil, store, 2, 1<<20, nil)
+
+		jobUsecase.On("Enqueue", []string{"/uploads/0/a.csv", "/uploads/1/b.csv"}, mock.MatchedBy(func(options domain.ImportOptions) bool {
+			return options.Profile == "supplier" && options.Dialect.Delimiter == ";"
+		})).Return(&domain.ImportJob{ID: "job-1"}, nil)
+		jobUsecase.On("Subscribe", "job-1").Return(nil, nil, domain.ErrJobNotFound).Maybe()
+
+		w := serve(h, newUploadRequest(t,
+			uploadPart{field: "profile", content: "supplier"},
+			uploadPart{field: "delimiter", content: ";"},
+			uploadPart{field: "files", fileName: "a.csv", content: "id\n1\n"},
+			uploadPart{field: "files", fileName: "b.csv", content: "id\n2\n"},
+		))
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Contains(t, w.Body.String(), "job-1")
+		assert.Empty(t, store.removedFiles())
+	})
+
+	t.Run("error - too many files", func(t *testing.T) {
+		jobUsecase := domain.NewMockImportJobUsecase(t)
+		store := &fakeFileStore{}
+		h := NewHandler(jobUsecase, nil, nil, store, 1, 1<<20, nil)
+
+		w := serve(h, newUploadRequest(t,
+			uploadPart{field: "files", fileName: "a.csv", content: "id\n1\n"},
+			uploadPart{field: "files", fileName: "b.csv", content: "id\n2\n"},
+		))
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Contains(t, w.Body.String(), domain.ErrTooManyFiles.Error())
+		assert.Equal(t, store.saved, store.removedFiles())
+	})
+
+	t.Run("error - request too large", func(t *testing.T) {
+		jobUsecase := domain.NewMockImportJobUsecase(t)
+		store := &fakeFileStore{}
+		h := NewHandler(jobUsecase, nil, nil, store, 2, 1024, nil)
+
+		w := serve(h, newUploadRequest(t,
+			uploadPart{field: "files", fileName: "a.csv", content: "id\n1\n"},
+			uploadPart{field: "files", fileName: "b.csv", content: strings.Repeat("x", 4096)},
+		))
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		require.Len(t, store.saved, 1)
+		assert.Equal(t, store.saved, store.removedFiles())
+	})
+
+	t.Run("error - form value too long", func(t *testing.T) {
+		jobUsecase := domain.NewMockImportJobUsecase(t)
+		store := &fakeFileStore{}
+		h := NewHandler(jobUsecase, nil, nil, store, 2, 1<<20, nil)
+
+		w := serve(h, newUploadRequest(t,
+			uploadPart{field: "files", fileName: "a.csv", content: "id\n1\n"},
+			uploadPart{field: "profile", content: strings.Repeat("p", maxFormValue+1)},
+		))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "profile is longer than 256 bytes")
+		assert.Equal(t, store.saved, store.removedFiles())
+	})
+
+	t.Run("error - job not queued", func(t *testing.T) {
+		jobUsecase := domain.NewMockImportJobUsecase(t)
+		store := &fakeFileStore{}
+		h := NewHandler(jobUsecase, nil, nil, store, 2, 1<<20, nil)
+
+		jobUsecase.On("Enqueue", []string{"/uploads/0/a.csv"}, domain.ImportOptions{}).Return(nil, domain.ErrQueueFull)
+
+		w := serve(h, newUploadRequest(t,
+			uploadPart{field: "files", fileName: "a.csv", content: "id\n1\n"},
+		))
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, store.saved, store.removedFiles())
+	})
+}
+
+func TestHandler_RemoveWhenFinished(t *testing.T) {
+	files := []*domain.StoredFile{{Name: "a.csv", Path: "/uploads/0/a.csv"}}
+
+	t.Run("success - removed after the summary", func(t *testing.T) {
+		jobUsecase := domain.NewMockImportJobUsecase(t)
+		store := &fakeFileStore{}
+		h := NewHandler(jobUsecase, nil, nil, store, 2, 1<<20, nil)
+
+		events := make(chan *domain.JobEvent, 2)
+		events <- &domain.JobEvent{Type: domain.JobEventProgress}
+		events <- &domain.JobEvent{Type: domain.JobEventSummary}
+		unsubscribed := false
+		jobUsecase.On("Subscribe", "job-1").Return((<-chan *domain.JobEvent)(events), func() { unsubscribed = true }, nil)
+
+		h.removeWhenFinished("job-1", files)
+
+		assert.Equal(t, files, store.removedFiles())
+		assert.True(t, unsubscribed)
+	})
+
+	t.Run("success - kept when the job cannot be followed", func(t *testing.T) {
+		jobUsecase := domain.NewMockImportJobUsecase(t)
+		store := &fakeFileStore{}
+		h := NewHandler(jobUsecase, nil, nil, store, 2, 1<<20, nil)
+
+		jobUsecase.On("Subscribe", "job-1").Return(nil, nil, errors.New("subscribe failed"))
+
+		h.removeWhenFinished("job-1", files)
+
+		assert.Empty(t, store.removedFiles())
+	})
+}
@@ -0,0 +1,165 @@
+// ============================================
+// internal/delivery/http/websocket.go
+// ============================================
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"data-processing/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	wsWriteTimeout = 10 * time.Second
+	wsPingInterval = 30 * time.Second
+)
+
+// newUpgrader accepts connections from the API's own origin and from
+// allowedOrigins such as the admin UI, so other pages cannot open the socket
+// cross-site to control jobs. Browsers always send Origin on a WebSocket
+// handshake, so only non-browser clients such as scripts reach the
+// empty-header branch.
+func newUpgrader(allowedOrigins []string) *websocket.Upgrader {
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		allowed[strings.ToLower(strings.TrimSuffix(origin, "/"))] = true
+	}
+
+	return &websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin: func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				return true
+			}
+			u, err := url.Parse(origin)
+			if err != nil {
+				return false
+			}
+			return strings.EqualFold(u.Host, r.Host) || allowed[strings.ToLower(origin)]
+		},
+	}
+}
+
+// JobControlMessage is a control command sent by the client
+type JobControlMessage struct {
+	Action string `json:"action" example:"pause"`
+}
+
+// JobSocketMessage is pushed to the client: job events ("progress",
+// "paused", "resumed", "summary"), command acknowledgements ("ack") and
+// command errors ("error").
+type JobSocketMessage struct {
+	Type   string      `json:"type"`
+	Action string      `json:"action,omitempty"`
+	Data   interface{} `json:"data,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// @Summary Import Job WebSocket
+// @Description Pushes job events and accepts {"action": "pause" | "resume" | "cancel"} control messages
+// @Tags jobs
+// @Param id path string true "Job ID"
+// @Success 101 {object} JobSocketMessage
+// @Failure 404 {object} map[string]interface{}
+// @Router /ws/jobs/{id} [get]
+func (h *Handler) JobWebSocket(c *gin.Context) {
+	jobID := c.Param("id")
+
+	events, unsubscribe, err := h.jobUsecase.Subscribe(jobID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	defer unsubscribe()
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrade has already replied to the client
+		return
+	}
+	defer conn.Close()
+
+	// Only this goroutine writes to the connection, so command replies are
+	// handed over from the reader.
+	replies := make(chan *JobSocketMessage, 8)
+	closed := make(chan struct{})
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(closed)
+		for {
+			var msg JobControlMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+
+			reply := &JobSocketMessage{Type: "ack", Action: msg.Action}
+			if err := h.jobUsecase.Control(jobID, domain.JobAction(msg.Action)); err != nil {
+				reply = &JobSocketMessage{Type: "error", Action: msg.Action, Error: err.Error()}
+			}
+
+			// Wait for the writer rather than drop the reply; done is
+			// closed once the writer gives up on the connection.
+			select {
+			case replies <- reply:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(wsPingInterval)
+	defer ping.Stop()
+
+	for {
+		var msg *JobSocketMessage
+		select {
+		case event, ok := <-events:
+			if !ok {
+				conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+				conn.WriteMessage(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseNormalClosure, "job finished"))
+				return
+			}
+			msg = socketMessage(event)
+		case msg = <-replies:
+		case <-ping.C:
+			conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+			continue
+		case <-closed:
+			return
+		}
+
+		conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+		if err := conn.WriteJSON(msg); err != nil {
+			return
+		}
+	}
+}
+
+func socketMessage(event *domain.JobEvent) *JobSocketMessage {
+	return &JobSocketMessage{Type: event.Type, Data: eventData(event)}
+}
+
+// eventData returns the payload of a job event.
+func eventData(event *domain.JobEvent) interface{} {
+	switch {
+	case event.Job != nil:
+		return event.Job
+	case event.Progress != nil:
+		return event.Progress
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,37 @@
+// ============================================
+// internal/delivery/http/websocket_test.go
+// ============================================
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUpgrader_CheckOrigin(t *testing.T) {
+	upgrader := newUpgrader([]string{"https://Admin.Example.com/"})
+
+	for _, tc := range []struct {
+		name   string
+		origin string
+		want   bool
+	}{
+		{"same host", "http://api.example.com:8080", true},
+		{"allowlisted", "https://admin.EXAMPLE.com", true},
+		{"foreign", "https://evil.example.net", false},
+		{"allowlisted host with other scheme", "http://admin.example.com", false},
+		{"malformed", "http://%zz", false},
+		{"missing", "", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://api.example.com:8080/ws/jobs/1", nil)
+			if tc.origin != "" {
+				r.Header.Set("Origin", tc.origin)
+			}
+
+			assert.Equal(t, tc.want, upgrader.CheckOrigin(r))
+		})
+	}
+}
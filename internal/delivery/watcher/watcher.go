@@ -0,0 +1,355 @@
+// ============================================
+// internal/delivery/watcher/watcher.go
+// ============================================
+package watcher
+
+import (
+	"context"
+	"data-processing/internal/domain"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	processedDir = "processed"
+	failedDir    = "failed"
+)
+
+// extensions lists the file types picked up from the inbox.
+var extensions = map[string]bool{
+	".csv":    true,
+	".xlsx":   true,
+	".json":   true,
+	".ndjson": true,
+	".jsonl":  true,
+	".gz":     true,
+	".zst":    true,
+	".zip":    true,
+}
+
+// Watcher imports files dropped into an inbox directory. A file is queued
+// once its size and modification time have not changed for the settle
+// period, so files still being written (e.g. over SFTP) are left alone.
+// Imported files are moved to processed/ or failed/ next to a JSON report.
+type Watcher struct {
+	jobs    domain.ImportJobUsecase
+	logger  domain.Logger
+	root    string
+	inbox   string
+	profile string
+	settle  time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*fileState
+	busy    map[string]struct{}
+	wg      sync.WaitGroup
+}
+
+// fileState is the last seen size and modification time of a pending file.
+type fileState struct {
+	size    int64
+	modTime time.Time
+	since   time.Time
+}
+
+// report is written next to every imported file.
+type report struct {
+	File         string           `json:"file"`
+	JobID        string           `json:"job_id,omitempty"`
+	Status       domain.JobStatus `json:"status"`
+	TotalRecords int              `json:"total_records"`
+	Inserted     int              `json:"inserted"`
+	Updated      int              `json:"updated"`
+	Failed       int              `json:"failed"`
+	Committed    int              `json:"committed"`
+	Errors       []string         `json:"errors,omitempty"`
+	Error        string           `json:"error,omitempty"`
+	FinishedAt   time.Time        `json:"finished_at"`
+}
+
+// NewWatcher creates a watcher for inbox, a directory inside the import
+// root, together with its processed/ and failed/ sub-directories. Files
+// are imported with the named profile, or the defaults when it is empty.
+func NewWatcher(
+	jobs domain.ImportJobUsecase,
+	logger domain.Logger,
+	root string,
+	inbox string,
+	profile string,
+	settle time.Duration,
+) (*Watcher, error) {
+	inbox = filepath.Clean(strings.TrimLeft(filepath.FromSlash(inbox), "/"))
+	if !filepath.IsLocal(inbox) {
+		return nil, fmt.Errorf("watch directory %s is outside %s", inbox, root)
+	}
+	if settle <= 0 {
+		return nil, fmt.Errorf("settle period must be positive, got %s", settle)
+	}
+
+	for _, dir := range []string{processedDir, failedDir} {
+		if err := os.MkdirAll(filepath.Join(root, inbox, dir), 0o755); err != nil {
+			return nil, fmt.Errorf("create watch directory: %w", err)
+		}
+	}
+
+	return &Watcher{
+		jobs:    jobs,
+		logger:  logger,
+		root:    root,
+		inbox:   inbox,
+		profile: profile,
+		settle:  settle,
+		pending: make(map[string]*fileState),
+		busy:    make(map[string]struct{}),
+	}, nil
+}
+
+// Run watches the inbox until ctx is done, then waits for the imports it
+// started to return. Files whose import was interrupted stay in the inbox
+// and are picked up again on the next start.
+func (w *Watcher) Run(ctx context.Context) error {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer fsWatcher.Close()
+
+	dir := w.dir()
+	if err := fsWatcher.Add(dir); err != nil {
+		return err
+	}
+
+	// Files dropped while the service was down
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		w.touch(entry.Name())
+	}
+
+	w.logger.Info("Watching %s for new files", dir)
+
+	ticker := time.NewTicker(w.settle / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-fsWatcher.Events:
+			if !ok {
+				w.wg.Wait()
+				return nil
+			}
+			if event.Has(fsnotify.Create) || event.Has(fsnotify.Write) {
+				w.touch(filepath.Base(event.Name))
+			}
+		case err, ok := <-fsWatcher.Errors:
+			if ok {
+				w.logger.Error("Watch %s: %v", dir, err)
+			}
+		case now := <-ticker.C:
+			for _, name := range w.settled(now) {
+				w.wg.Add(1)
+				go func(name string) {
+					defer w.wg.Done()
+					w.process(ctx, name)
+				}(name)
+			}
+		case <-ctx.Done():
+			w.wg.Wait()
+			return nil
+		}
+	}
+}
+
+func (w *Watcher) dir() string {
+	return filepath.Join(w.root, w.inbox)
+}
+
+// touch starts or restarts the settle period of an inbox file.
+func (w *Watcher) touch(name string) {
+	if strings.HasPrefix(name, ".") || !extensions[strings.ToLower(filepath.Ext(name))] {
+		return
+	}
+
+	info, err := os.Stat(filepath.Join(w.dir(), name))
+	if err != nil || !info.Mode().IsRegular() {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.busy[name]; ok {
+		return
+	}
+	w.pending[name] = &fileState{size: info.Size(), modTime: info.ModTime(), since: time.Now()}
+}
+
+// settled returns the pending files that have not changed for the settle
+// period and marks them busy.
+func (w *Watcher) settled(now time.Time) []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var names []string
+	for name, state := range w.pending {
+		info, err := os.Stat(filepath.Join(w.dir(), name))
+		if err != nil {
+			delete(w.pending, name)
+			continue
+		}
+		if info.Size() != state.size || !info.ModTime().Equal(state.modTime) {
+			*state = fileState{size: info.Size(), modTime: info.ModTime(), since: now}
+			continue
+		}
+		if now.Sub(state.since) < w.settle {
+			continue
+		}
+
+		delete(w.pending, name)
+		w.busy[name] = struct{}{}
+		names = append(names, name)
+	}
+	return names
+}
+
+// process imports one file and files it under processed/ or failed/.
+func (w *Watcher) process(ctx context.Context, name string) {
+	defer w.release(name)
+
+	importPath := "/" + filepath.ToSlash(filepath.Join(w.inbox, name))
+	job, err := w.jobs.Enqueue([]string{importPath}, domain.ImportOptions{Profile: w.profile})
+	if err != nil {
+		if errors.Is(err, domain.ErrQueueFull) {
+			// Try again after another settle period
+			w.logger.Info("Watch: %s not queued: %v", name, err)
+			w.release(name)
+			w.touch(name)
+			return
+		}
+		if errors.Is(err, domain.ErrQueueClosed) {
+			return
+		}
+		w.file(name, &report{File: name, Status: domain.JobFailed, Error: err.Error(), FinishedAt: time.Now()})
+		return
+	}
+
+	summary, err := w.wait(ctx, job.ID)
+	if err != nil {
+		w.logger.Error("Watch: %s: %v", name, err)
+		return
+	}
+	if summary == nil {
+		// Shutting down, the file is imported again on the next start
+		return
+	}
+	if summary.Status == domain.JobCancelled && ctx.Err() != nil {
+		return
+	}
+
+	finishedAt := time.Now()
+	if summary.FinishedAt != nil {
+		finishedAt = *summary.FinishedAt
+	}
+	w.file(name, &report{
+		File:         name,
+		JobID:        summary.ID,
+		Status:       summary.Status,
+		TotalRecords: summary.TotalRecords,
+		Inserted:     summary.Inserted,
+		Updated:      summary.Updated,
+		Failed:       summary.Failed,
+		Committed:    summary.Committed,
+		Errors:       summary.Errors,
+		Error:        summary.Error,
+		FinishedAt:   finishedAt,
+	})
+}
+
+func (w *Watcher) release(name string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.busy, name)
+}
+
+// wait returns the finished job, or nil once ctx is done.
+func (w *Watcher) wait(ctx context.Context, jobID string) (*domain.ImportJob, error) {
+	events, unsubscribe, err := w.jobs.Subscribe(jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer unsubscribe()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return nil, fmt.Errorf("job %s ended without a summary", jobID)
+			}
+			if event.Type == domain.JobEventSummary && event.Job != nil {
+				return event.Job, nil
+			}
+		case <-ctx.Done():
+			return nil, nil
+		}
+	}
+}
+
+// file moves an imported file to processed/ when its job succeeded, or to
+// failed/ otherwise, and writes the report next to it.
+func (w *Watcher) file(name string, result *report) {
+	dir := failedDir
+	if result.Status == domain.JobSucceeded {
+		dir = processedDir
+	}
+
+	dest := uniquePath(filepath.Join(w.dir(), dir, name))
+	if err := os.Rename(filepath.Join(w.dir(), name), dest); err != nil {
+		w.logger.Error("Watch: move %s: %v", name, err)
+		return
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err == nil {
+		err = writeReport(dest+".json", data)
+	}
+	if err != nil {
+		w.logger.Error("Watch: write report for %s: %v", name, err)
+	}
+
+	w.logger.Info("Watch: %s %s, moved to %s", name, result.Status, dir)
+}
+
+// writeReport writes data to a hidden file renamed to path once complete,
+// so a report that exists is never partial.
+func writeReport(path string, data []byte) error {
+	tmp := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// uniquePath adds a timestamp to path when a file with that name was
+// already filed, so earlier reports are kept.
+func uniquePath(path string) string {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return path
+	}
+
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s-%s%s", strings.TrimSuffix(path, ext), time.Now().Format("20060102T150405.000000000"), ext)
+}
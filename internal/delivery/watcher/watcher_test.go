@@ -0,0 +1,165 @@
+// ============================================
+// internal/delivery/watcher/watcher_test.go
+// ============================================
+package watcher
+
+import (
+	"context"
+	"data-processing/internal/domain"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testSettle = 50 * time.Millisecond
+
+// startWatcher runs a watcher on root/inbox until the test ends.
+func startWatcher(t *testing.T, jobs domain.ImportJobUsecase, root string) {
+	mockLogger := domain.NewMockLogger(t)
+	mockLogger.On("Info", mock.Anything, mock.Anything).Return().Maybe()
+
+	w, err := NewWatcher(jobs, mockLogger, root, "inbox", "supplier", testSettle)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, w.Run(ctx))
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+// summary returns a closed event channel holding the job's summary.
+func summary(job *domain.ImportJob) <-chan *domain.JobEvent {
+	events := make(chan *domain.JobEvent, 1)
+	events <- &domain.JobEvent{Type: domain.JobEventSummary, Job: job}
+	close(events)
+	return events
+}
+
+func readReport(t *testing.T, path string) *report {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var result report
+	require.NoError(t, json.Unmarshal(data, &result))
+	return &result
+}
+
+func TestWatcher_Run(t *testing.T) {
+	t.Run("success - moves imported file to processed", func(t *testing.T) {
+		root := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "inbox"), 0o755))
+		// Dropped before the watcher starts
+		require.NoError(t, os.WriteFile(filepath.Join(root, "inbox", "products.csv"), []byte("Id\n1\n"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(root, "inbox", ".products.csv.part"), []byte("Id\n"), 0o644))
+
+		mockJobs := domain.NewMockImportJobUsecase(t)
+		mockJobs.On("Enqueue", []string{"/inbox/products.csv"}, domain.ImportOptions{Profile: "supplier"}).
+			Return(&domain.ImportJob{ID: "job-1", Status: domain.JobQueued}, nil).Once()
+		mockJobs.On("Subscribe", "job-1").Return(summary(&domain.ImportJob{
+			ID:           "job-1",
+			Status:       domain.JobSucceeded,
+			TotalRecords: 1,
+			Inserted:     1,
+			Committed:    1,
+		}), func() {}, nil).Once()
+
+		startWatcher(t, mockJobs, root)
+
+		reportPath := filepath.Join(root, "inbox", "processed", "products.csv.json")
+		require.Eventually(t, func() bool {
+			_, err := os.Stat(reportPath)
+			return err == nil
+		}, 2*time.Second, 10*time.Millisecond)
+
+		assert.FileExists(t, filepath.Join(root, "inbox", "processed", "products.csv"))
+		assert.NoFileExists(t, filepath.Join(root, "inbox", "products.csv"))
+		assert.FileExists(t, filepath.Join(root, "inbox", ".products.csv.part"))
+
+		result := readReport(t, reportPath)
+		assert.Equal(t, "job-1", result.JobID)
+		assert.Equal(t, domain.JobSucceeded, result.Status)
+		assert.Equal(t, 1, result.Inserted)
+		assert.Equal(t, 1, result.Committed)
+	})
+
+	t.Run("success - moves failed import to failed", func(t *testing.T) {
+		root := t.TempDir()
+
+		mockJobs := domain.NewMockImportJobUsecase(t)
+		mockJobs.On("Enqueue", []string{"/inbox/products.csv"}, domain.ImportOptions{Profile: "supplier"}).
+			Return(&domain.ImportJob{ID: "job-1", Status: domain.JobQueued}, nil).Once()
+		mockJobs.On("Subscribe", "job-1").Return(summary(&domain.ImportJob{
+			ID:     "job-1",
+			Status: domain.JobFailed,
+			Error:  "no files could be processed",
+		}), func() {}, nil).Once()
+
+		startWatcher(t, mockJobs, root)
+		require.NoError(t, os.WriteFile(filepath.Join(root, "inbox", "products.csv"), []byte("Id\n1\n"), 0o644))
+
+		reportPath := filepath.Join(root, "inbox", "failed", "products.csv.json")
+		require.Eventually(t, func() bool {
+			_, err := os.Stat(reportPath)
+			return err == nil
+		}, 2*time.Second, 10*time.Millisecond)
+
+		assert.FileExists(t, filepath.Join(root, "inbox", "failed", "products.csv"))
+		result := readReport(t, reportPath)
+		assert.Equal(t, domain.JobFailed, result.Status)
+		assert.Equal(t, "no files could be processed", result.Error)
+	})
+
+	t.Run("error - job not queued", func(t *testing.T) {
+		root := t.TempDir()
+
+		mockJobs := domain.NewMockImportJobUsecase(t)
+		mockJobs.On("Enqueue", []string{"/inbox/products.csv"}, domain.ImportOptions{Profile: "supplier"}).
+			Return(nil, domain.ErrProfileNotFound).Once()
+
+		startWatcher(t, mockJobs, root)
+		require.NoError(t, os.WriteFile(filepath.Join(root, "inbox", "products.csv"), []byte("Id\n1\n"), 0o644))
+
+		reportPath := filepath.Join(root, "inbox", "failed", "products.csv.json")
+		require.Eventually(t, func() bool {
+			_, err := os.Stat(reportPath)
+			return err == nil
+		}, 2*time.Second, 10*time.Millisecond)
+
+		result := readReport(t, reportPath)
+		assert.Empty(t, result.JobID)
+		assert.Equal(t, domain.JobFailed, result.Status)
+		assert.Equal(t, domain.ErrProfileNotFound.Error(), result.Error)
+	})
+}
+
+func TestNewWatcher(t *testing.T) {
+	t.Run("error - directory outside root", func(t *testing.T) {
+		w, err := NewWatcher(nil, nil, t.TempDir(), "../inbox", "", testSettle)
+
+		assert.Error(t, err)
+		assert.Nil(t, w)
+	})
+}
+
+func TestUniquePath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "products.csv")
+	assert.Equal(t, path, uniquePath(path))
+
+	require.NoError(t, os.WriteFile(path, nil, 0o644))
+	unique := uniquePath(path)
+	assert.NotEqual(t, path, unique)
+	assert.Equal(t, ".csv", filepath.Ext(unique))
+}
@@ -4,6 +4,11 @@
 package domain
 
 import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
 	"time"
 )
 
@@ -20,11 +25,335 @@ type Product struct {
 	Ean          string
 	Color        string
 	Size         string
-	Availability string
+	Availability Availability
 	InternalId   int
-	CreatedAt    time.Time
+	// OriginalPrice and OriginalCurrency keep the price as imported when
+	// it was converted into the base currency
+	OriginalPrice    *float64
+	OriginalCurrency string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	CreatedBy        string `gorm:"not null"`
+}
+
+// Availability is the stock status of a product
+type Availability string
+
+const (
+	AvailabilityInStock      Availability = "in_stock"
+	AvailabilityLimitedStock Availability = "limited_stock"
+	AvailabilityOutOfStock   Availability = "out_of_stock"
+	AvailabilityBackorder    Availability = "backorder"
+	AvailabilityPreOrder     Availability = "pre_order"
+	AvailabilityDiscontinued Availability = "discontinued"
+)
+
+// Availabilities lists every availability
+var Availabilities = []Availability{
+	AvailabilityInStock,
+	AvailabilityLimitedStock,
+	AvailabilityOutOfStock,
+	AvailabilityBackorder,
+	AvailabilityPreOrder,
+	AvailabilityDiscontinued,
+}
+
+// Valid reports whether a is one of Availabilities
+func (a Availability) Valid() bool {
+	for _, availability := range Availabilities {
+		if a == availability {
+			return true
+		}
+	}
+	return false
+}
+
+// ImportProfile describes how a supplier feed is laid out
+type ImportProfile struct {
+	Name             string `gorm:"primarykey"`
+	ColumnMapping    StringMap
+	Delimiter        string
+	Quote            string
+	Comment          string
+	LazyQuotes       bool
+	Encoding         string
+	DecimalSeparator string
+	Transforms       FieldTransforms
+	// Rules add validation rules to the product fields
+	Rules ValidationRules
+	// EanStrictness (off, flag or reject) and EanFormat (ean13 or gtin14)
+	// control EAN check digit validation and normalisation
+	EanStrictness string
+	EanFormat     string
+	// ConvertCurrency converts prices into the base currency of the catalog
+	ConvertCurrency bool
+	// AvailabilitySynonyms maps feed values to availabilities, on top of
+	// the built-in synonyms
+	AvailabilitySynonyms StringMap
+	// AvailabilityStock (off, derive, flag or reject) checks availability
+	// against stock
+	AvailabilityStock string
+	// Sheet and HeaderRow locate the product table in XLSX workbooks
+	Sheet     string
+	HeaderRow int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ExchangeRate is the value of one unit of Currency in BaseCurrency
+type ExchangeRate struct {
+	Currency     string `gorm:"primarykey"`
+	BaseCurrency string `gorm:"primarykey"`
+	Rate         float64
 	UpdatedAt    time.Time
-	CreatedBy    string `gorm:"not null"`
+}
+
+// JobStatus represents the lifecycle state of an import job
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+	JobCancelled JobStatus = "cancelled"
+)
+
+// ImportJob tracks an asynchronous CSV import
+type ImportJob struct {
+	ID             string `gorm:"primarykey"`
+	Status         JobStatus
+	FilePaths      StringList
+	Profile        string
+	Dialect        CSVDialect
+	ErrorPolicy    ErrorPolicy
+	Atomic         AtomicScope
+	TotalRecords   int
+	Inserted       int
+	Updated        int
+	Failed         int
+	Committed      int
+	Errors         StringList
+	FileResults    FileResultMap
+	Error          string
+	ProcessingTime time.Duration
+	CreatedAt      time.Time
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+}
+
+// ApplyResult copies the final processing statistics onto the job
+func (j *ImportJob) ApplyResult(result *FinalResult) {
+	j.TotalRecords = result.TotalRecords
+	j.Inserted = result.Inserted
+	j.Updated = result.Updated
+	j.Failed = result.Failed
+	j.Committed = result.Committed
+	j.Errors = result.Errors
+	j.FileResults = result.FileResults
+	j.ProcessingTime = result.ProcessingTime
+}
+
+// Finished reports whether the job has reached a terminal state
+func (j *ImportJob) Finished() bool {
+	switch j.Status {
+	case JobSucceeded, JobFailed, JobCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// JobAction is a control command for a running import job
+type JobAction string
+
+const (
+	JobActionPause  JobAction = "pause"
+	JobActionResume JobAction = "resume"
+	JobActionCancel JobAction = "cancel"
+)
+
+// JobControl lets a run be paused, resumed or cancelled between rows
+type JobControl interface {
+	// Checkpoint blocks while the run is paused and returns an error
+	// wrapping ErrJobCancelled once it has been cancelled or ctx is done
+	Checkpoint(ctx context.Context) error
+}
+
+// ImportOptions holds per-request processing options
+type ImportOptions struct {
+	Profile string
+	Control JobControl
+	// Recursive includes files in sub-directories of directory inputs
+	Recursive bool
+	// Dialect overrides how the profile parses CSV files
+	Dialect CSVDialect
+	// ErrorPolicy says when a file is given up on
+	ErrorPolicy ErrorPolicy
+	// Atomic says which writes are committed together
+	Atomic AtomicScope
+}
+
+// AtomicScope says which writes of an import are committed together
+type AtomicScope string
+
+const (
+	// AtomicOff commits every batch as it is written
+	AtomicOff AtomicScope = ""
+	// AtomicFile commits the rows of each file together
+	AtomicFile AtomicScope = "file"
+	// AtomicRequest commits the rows of every file of the request together
+	AtomicRequest AtomicScope = "request"
+)
+
+// Valid reports whether s is a known scope
+func (s AtomicScope) Valid() bool {
+	switch s {
+	case AtomicOff, AtomicFile, AtomicRequest:
+		return true
+	default:
+		return false
+	}
+}
+
+// CSVDialect overrides the CSV parsing settings of a profile for one
+// request. Empty fields keep the profile's settings, and LazyQuotes
+// overrides it in either direction when set. Stored as a JSONB column
+type CSVDialect struct {
+	Delimiter  string
+	Quote      string
+	Comment    string
+	LazyQuotes *bool
+	Encoding   string
+}
+
+func (d CSVDialect) Value() (driver.Value, error) {
+	return jsonValue(d)
+}
+
+func (d *CSVDialect) Scan(value interface{}) error {
+	return jsonScan(value, d)
+}
+
+// ErrorPolicy says when an import aborts a file because of failed rows.
+// The zero policy keeps going whatever fails. Stored as a JSONB column
+type ErrorPolicy struct {
+	// FailFast aborts a file on its first failed row
+	FailFast bool
+	// MaxFailures aborts a file once this many rows have failed
+	MaxFailures int
+	// MaxFailureRate aborts a file once more than this percentage of its
+	// rows have failed, checked from the 100th row and at the end
+	MaxFailureRate float64
+	// Rollback undoes the writes of an aborted file, which are otherwise
+	// kept
+	Rollback bool
+}
+
+// Enabled reports whether the policy can abort a file
+func (p ErrorPolicy) Enabled() bool {
+	return p.FailFast || p.MaxFailures > 0 || p.MaxFailureRate > 0
+}
+
+func (p ErrorPolicy) Value() (driver.Value, error) {
+	return jsonValue(p)
+}
+
+func (p *ErrorPolicy) Scan(value interface{}) error {
+	return jsonScan(value, p)
+}
+
+// StringMap is a string map stored as a JSONB column
+type StringMap map[string]string
+
+func (m StringMap) Value() (driver.Value, error) {
+	return jsonValue(m)
+}
+
+func (m *StringMap) Scan(value interface{}) error {
+	return jsonScan(value, m)
+}
+
+// FieldTransforms maps a column name to the transforms applied to it,
+// stored as a JSONB column
+type FieldTransforms map[string][]string
+
+func (t FieldTransforms) Value() (driver.Value, error) {
+	return jsonValue(t)
+}
+
+func (t *FieldTransforms) Scan(value interface{}) error {
+	return jsonScan(value, t)
+}
+
+// FieldRule declares the checks applied to the value of one product field.
+// Zero values are not checked
+type FieldRule struct {
+	Required bool
+	// Min and Max bound numeric fields
+	Min *float64
+	Max *float64
+	// MaxLength is counted in characters
+	MaxLength int
+	// Pattern is a regular expression the whole value must match
+	Pattern string
+	// Allowed lists the only values accepted
+	Allowed []string
+}
+
+// ValidationRules maps a product field name to its rule, stored as a JSONB
+// column
+type ValidationRules map[string]FieldRule
+
+func (r ValidationRules) Value() (driver.Value, error) {
+	return jsonValue(r)
+}
+
+func (r *ValidationRules) Scan(value interface{}) error {
+	return jsonScan(value, r)
+}
+
+// StringList is a string slice stored as a JSONB column
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	return jsonValue(l)
+}
+
+func (l *StringList) Scan(value interface{}) error {
+	return jsonScan(value, l)
+}
+
+// FileResultMap holds per-file statistics stored as a JSONB column
+type FileResultMap map[string]*FileResult
+
+func (m FileResultMap) Value() (driver.Value, error) {
+	return jsonValue(m)
+}
+
+func (m *FileResultMap) Scan(value interface{}) error {
+	return jsonScan(value, m)
+}
+
+func jsonValue(v interface{}) (driver.Value, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func jsonScan(value interface{}, dest interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	default:
+		return fmt.Errorf("cannot scan %T into %T", value, dest)
+	}
 }
 
 // CSVRecord represents raw CSV data
@@ -49,22 +378,36 @@ type CSVRecord struct {
 type ProcessJob struct {
 	Record   *CSVRecord
 	FilePath string
+	// Error is set for a row that could not be read, Record then only
+	// holds its row number
+	Error error
 }
 
 // ProcessResult holds processing statistics
 type ProcessResult struct {
-	Product   *Product
-	IsUpdate  bool
-	Error     error
+	Product  *Product
+	IsUpdate bool
+	Error    error
+	// Warning reports a problem that did not fail the row
+	Warning   string
 	RowNumber int
 	FilePath  string
 }
 
-// ProgressUpdate represents real-time progress
+// UpsertOutcome reports whether an upserted product was inserted or updated
+type UpsertOutcome struct {
+	ID       int
+	Inserted bool
+}
+
+// ProgressUpdate represents real-time progress. Percentage is measured
+// against the bytes read, as the record count is only known at the end.
 type ProgressUpdate struct {
 	FileName       string
 	TotalRecords   int
 	ProcessedCount int
+	BytesRead      int64
+	TotalBytes     int64
 	Percentage     float64
 	Inserted       int
 	Updated        int
@@ -72,6 +415,21 @@ type ProgressUpdate struct {
 	Message        string
 }
 
+// JobEvent types streamed to progress subscribers
+const (
+	JobEventProgress = "progress"
+	JobEventPaused   = "paused"
+	JobEventResumed  = "resumed"
+	JobEventSummary  = "summary"
+)
+
+// JobEvent is a progress update or the final summary of an import job
+type JobEvent struct {
+	Type     string
+	Progress *ProgressUpdate
+	Job      *ImportJob
+}
+
 // FinalResult holds final processing statistics
 type FinalResult struct {
 	TotalRecords   int
@@ -80,7 +438,38 @@ type FinalResult struct {
 	Failed         int
 	Errors         []string
 	ProcessingTime time.Duration
-	FileResults    map[string]*FileResult
+	FileResults    FileResultMap
+	// Committed counts the rows written to the database
+	Committed    int
+	Cancelled    bool
+	CancelReason string
+	// RolledBack is set when an atomic request was rolled back, undoing
+	// the writes of every file
+	RolledBack     bool
+	RollbackReason string
+}
+
+// AddFileResult records the statistics of a processed file
+func (r *FinalResult) AddFileResult(filePath string, fileResult *FileResult) {
+	r.FileResults[filePath] = fileResult
+	r.TotalRecords += fileResult.TotalRecords
+	r.Inserted += fileResult.Inserted
+	r.Updated += fileResult.Updated
+	r.Failed += fileResult.Failed
+	r.Committed += fileResult.Committed
+	r.Errors = append(r.Errors, fileResult.Errors...)
+}
+
+// FilesProcessed counts the files that were read to the end and not
+// aborted
+func (r *FinalResult) FilesProcessed() int {
+	processed := 0
+	for _, fileResult := range r.FileResults {
+		if fileResult.Error == "" && !fileResult.Aborted {
+			processed++
+		}
+	}
+	return processed
 }
 
 // FileResult holds per-file statistics
@@ -89,21 +478,119 @@ type FileResult struct {
 	Inserted     int
 	Updated      int
 	Failed       int
+	Committed    int
 	Errors       []string
+	// Error is set when the file could not be read at all, or not to the
+	// end
+	Error string
+	// Aborted is set when the error policy stopped the file, for the
+	// reason in AbortReason. Rows after the abort are not counted
+	Aborted     bool
+	AbortReason string
+	// RolledBack is set when the writes of the file were undone, which
+	// then counts no rows as inserted, updated or committed
+	RolledBack bool
+}
+
+// StoredFile is an uploaded file saved for import
+type StoredFile struct {
+	Name   string
+	Path   string
+	Size   int64
+	SHA256 string
 }
 
 // ProductRepository defines repository interface
 type ProductRepository interface {
-	Create(product *Product) error
-	Update(product *Product) error
-	FindById(id int) (*Product, error)
-	BulkUpsert(products []*Product) error
-	GetAll() ([]*Product, error)
+	Create(ctx context.Context, product *Product) error
+	Update(ctx context.Context, product *Product) error
+	FindById(ctx context.Context, id int) (*Product, error)
+	// BulkUpsert writes products, which must have distinct IDs, and
+	// reports for each whether it was inserted or updated
+	BulkUpsert(ctx context.Context, products []*Product) ([]UpsertOutcome, error)
+	GetAll(ctx context.Context) ([]*Product, error)
+	// Transaction runs fn with a repository whose writes are committed
+	// together when fn returns nil and rolled back otherwise. A failed
+	// BulkUpsert inside only undoes its own writes, so fn returns its
+	// error to undo the others
+	Transaction(ctx context.Context, fn func(repo ProductRepository) error) error
+}
+
+// ImportProfileRepository defines import profile repository interface
+type ImportProfileRepository interface {
+	Save(profile *ImportProfile) error
+	FindByName(name string) (*ImportProfile, error)
+	GetAll() ([]*ImportProfile, error)
+}
+
+// ExchangeRateRepository defines exchange rate repository interface
+type ExchangeRateRepository interface {
+	Save(rate *ExchangeRate) error
+	// FindByBase returns the rates into base keyed by currency
+	FindByBase(base string) (map[string]float64, error)
+	GetAll() ([]*ExchangeRate, error)
+}
+
+// ImportJobRepository defines import job repository interface
+type ImportJobRepository interface {
+	Create(job *ImportJob) error
+	Update(job *ImportJob) error
+	FindByID(id string) (*ImportJob, error)
+	List(status JobStatus, limit int) ([]*ImportJob, error)
 }
 
 // CSVProcessorUsecase defines usecase interfaceace
 type CSVProcessorUsecase interface {
-	ProcessCSVFiles(filePaths []string, progressChan chan<- *ProgressUpdate) (*FinalResult, error)
+	ProcessCSVFiles(ctx context.Context, filePaths []string, options ImportOptions, progressChan chan<- *ProgressUpdate) (*FinalResult, error)
+}
+
+// ImportJobUsecase defines import job usecase interface
+type ImportJobUsecase interface {
+	Enqueue(filePaths []string, options ImportOptions) (*ImportJob, error)
+	GetJob(id string) (*ImportJob, error)
+	ListJobs(status JobStatus, limit int) ([]*ImportJob, error)
+	Subscribe(id string) (<-chan *JobEvent, func(), error)
+	Control(id string, action JobAction) error
+	// Shutdown stops accepting jobs, cancels the running ones and waits
+	// for their outcome to be saved
+	Shutdown(ctx context.Context) error
+}
+
+// PathResolver maps an import path from a request to a file it is
+// allowed to read
+type PathResolver interface {
+	Resolve(path string) (string, error)
+	// Expand lists the files named by a path, directory or glob pattern
+	Expand(path string, recursive bool) ([]string, error)
+}
+
+// FileStore defines where uploaded files are kept for import
+type FileStore interface {
+	Save(name string, r io.Reader) (*StoredFile, error)
+	Remove(file *StoredFile) error
+}
+
+// RecordSource streams records from one import file, whatever its format
+type RecordSource interface {
+	// Next returns the next record, or io.EOF once the file is exhausted
+	Next() (*CSVRecord, error)
+	// Offset and Size report progress through the file in bytes
+	Offset() int64
+	Size() int64
+	Close() error
+}
+
+// ImportProfileUsecase defines import profile usecase interface
+type ImportProfileUsecase interface {
+	SaveProfile(profile *ImportProfile) error
+	GetProfile(name string) (*ImportProfile, error)
+	ListProfiles() ([]*ImportProfile, error)
+}
+
+// ExchangeRateUsecase defines exchange rate usecase interface
+type ExchangeRateUsecase interface {
+	SaveRate(rate *ExchangeRate) error
+	ListRates() ([]*ExchangeRate, error)
 }
 
 // Logger defines logger interface
@@ -0,0 +1,73 @@
+// ============================================
+// internal/domain/errors.go
+// ============================================
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrProfileNotFound is returned when an import profile does not exist
+	ErrProfileNotFound = errors.New("import profile not found")
+	// ErrInvalidProfile is returned when an import profile cannot be applied
+	ErrInvalidProfile = errors.New("invalid import profile")
+	// ErrInvalidDialect is returned when the CSV options of a request
+	// cannot be applied
+	ErrInvalidDialect = errors.New("invalid CSV options")
+	// ErrInvalidErrorPolicy is returned when the error policy of a request
+	// cannot be applied
+	ErrInvalidErrorPolicy = errors.New("invalid error policy")
+	// ErrInvalidAtomicScope is returned for an unknown atomic import scope
+	ErrInvalidAtomicScope = errors.New("invalid atomic scope")
+	// ErrInvalidExchangeRate is returned when an exchange rate cannot be
+	// saved
+	ErrInvalidExchangeRate = errors.New("invalid exchange rate")
+	// ErrJobNotFound is returned when an import job does not exist
+	ErrJobNotFound = errors.New("import job not found")
+	// ErrQueueFull is returned when no more import jobs can be queued
+	ErrQueueFull = errors.New("import queue is full")
+	// ErrQueueClosed is returned when enqueuing after shutdown has begun
+	ErrQueueClosed = errors.New("import queue is closed")
+	// ErrPathNotAllowed is returned for an import path outside the import
+	// directory
+	ErrPathNotAllowed = errors.New("path not allowed")
+	// ErrNoMatchingFiles is returned when a directory or glob pattern names
+	// no files
+	ErrNoMatchingFiles = errors.New("no matching files")
+	// ErrFileTooLarge is returned when an upload exceeds the size limit
+	ErrFileTooLarge = errors.New("file too large")
+	// ErrTooManyFiles is returned when an upload has more files than
+	// allowed
+	ErrTooManyFiles = errors.New("too many files")
+	// ErrJobNotActive is returned when controlling a job that is not queued
+	// or running in this process
+	ErrJobNotActive = errors.New("import job is not active")
+	// ErrInvalidJobAction is returned for an unknown job control action
+	ErrInvalidJobAction = errors.New("invalid job action")
+	// ErrJobCancelled is returned when an import is cancelled mid-run
+	ErrJobCancelled = errors.New("import job cancelled")
+	// ErrJobTimeout is the cancellation cause of a job that ran past its
+	// deadline
+	ErrJobTimeout = fmt.Errorf("%w: deadline exceeded", ErrJobCancelled)
+	// ErrShuttingDown is the cancellation cause of jobs interrupted by a
+	// server shutdown
+	ErrShuttingDown = fmt.Errorf("%w: server shutting down", ErrJobCancelled)
+)
+
+// RowError is returned by a RecordSource for a row that cannot be read,
+// such as one with the wrong number of fields or broken quoting. The row
+// has been consumed, so the next call to Next continues after it.
+type RowError struct {
+	Row int
+	Err error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
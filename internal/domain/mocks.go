@@ -5,9 +5,90 @@
 package domain
 
 import (
+	"context"
+	"io"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
+// NewMockJobControl creates a new instance of MockJobControl. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockJobControl(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockJobControl {
+	mock := &MockJobControl{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockJobControl is an autogenerated mock type for the JobControl type
+type MockJobControl struct {
+	mock.Mock
+}
+
+type MockJobControl_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockJobControl) EXPECT() *MockJobControl_Expecter {
+	return &MockJobControl_Expecter{mock: &_m.Mock}
+}
+
+// Checkpoint provides a mock function for the type MockJobControl
+func (_mock *MockJobControl) Checkpoint(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Checkpoint")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockJobControl_Checkpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Checkpoint'
+type MockJobControl_Checkpoint_Call struct {
+	*mock.Call
+}
+
+// Checkpoint is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockJobControl_Expecter) Checkpoint(ctx interface{}) *MockJobControl_Checkpoint_Call {
+	return &MockJobControl_Checkpoint_Call{Call: _e.mock.On("Checkpoint", ctx)}
+}
+
+func (_c *MockJobControl_Checkpoint_Call) Run(run func(ctx context.Context)) *MockJobControl_Checkpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobControl_Checkpoint_Call) Return(err error) *MockJobControl_Checkpoint_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockJobControl_Checkpoint_Call) RunAndReturn(run func(ctx context.Context) error) *MockJobControl_Checkpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockProductRepository creates a new instance of MockProductRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockProductRepository(t interface {
@@ -36,20 +117,31 @@ func (_m *MockProductRepository) EXPECT() *MockProductRepository_Expecter {
 }
 
 // BulkUpsert provides a mock function for the type MockProductRepository
-func (_mock *MockProductRepository) BulkUpsert(products []*Product) error {
-	ret := _mock.Called(products)
+func (_mock *MockProductRepository) BulkUpsert(ctx context.Context, products []*Product) ([]UpsertOutcome, error) {
+	ret := _mock.Called(ctx, products)
 
 	if len(ret) == 0 {
 		panic("no return value specified for BulkUpsert")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func([]*Product) error); ok {
-		r0 = returnFunc(products)
+	var r0 []UpsertOutcome
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*Product) ([]UpsertOutcome, error)); ok {
+		return returnFunc(ctx, products)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*Product) []UpsertOutcome); ok {
+		r0 = returnFunc(ctx, products)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]UpsertOutcome)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []*Product) error); ok {
+		r1 = returnFunc(ctx, products)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
 // MockProductRepository_BulkUpsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkUpsert'
@@ -58,45 +150,51 @@ type MockProductRepository_BulkUpsert_Call struct {
 }
 
 // BulkUpsert is a helper method to define mock.On call
+//   - ctx context.Context
 //   - products []*Product
-func (_e *MockProductRepository_Expecter) BulkUpsert(products interface{}) *MockProductRepository_BulkUpsert_Call {
-	return &MockProductRepository_BulkUpsert_Call{Call: _e.mock.On("BulkUpsert", products)}
+func (_e *MockProductRepository_Expecter) BulkUpsert(ctx interface{}, products interface{}) *MockProductRepository_BulkUpsert_Call {
+	return &MockProductRepository_BulkUpsert_Call{Call: _e.mock.On("BulkUpsert", ctx, products)}
 }
 
-func (_c *MockProductRepository_BulkUpsert_Call) Run(run func(products []*Product)) *MockProductRepository_BulkUpsert_Call {
+func (_c *MockProductRepository_BulkUpsert_Call) Run(run func(ctx context.Context, products []*Product)) *MockProductRepository_BulkUpsert_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 []*Product
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].([]*Product)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*Product
+		if args[1] != nil {
+			arg1 = args[1].([]*Product)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockProductRepository_BulkUpsert_Call) Return(err error) *MockProductRepository_BulkUpsert_Call {
-	_c.Call.Return(err)
+func (_c *MockProductRepository_BulkUpsert_Call) Return(upsertOutcomes []UpsertOutcome, err error) *MockProductRepository_BulkUpsert_Call {
+	_c.Call.Return(upsertOutcomes, err)
 	return _c
 }
 
-func (_c *MockProductRepository_BulkUpsert_Call) RunAndReturn(run func(products []*Product) error) *MockProductRepository_BulkUpsert_Call {
+func (_c *MockProductRepository_BulkUpsert_Call) RunAndReturn(run func(ctx context.Context, products []*Product) ([]UpsertOutcome, error)) *MockProductRepository_BulkUpsert_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Create provides a mock function for the type MockProductRepository
-func (_mock *MockProductRepository) Create(product *Product) error {
-	ret := _mock.Called(product)
+func (_mock *MockProductRepository) Create(ctx context.Context, product *Product) error {
+	ret := _mock.Called(ctx, product)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(*Product) error); ok {
-		r0 = returnFunc(product)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Product) error); ok {
+		r0 = returnFunc(ctx, product)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -109,19 +207,25 @@ type MockProductRepository_Create_Call struct {
 }
 
 // Create is a helper method to define mock.On call
+//   - ctx context.Context
 //   - product *Product
-func (_e *MockProductRepository_Expecter) Create(product interface{}) *MockProductRepository_Create_Call {
-	return &MockProductRepository_Create_Call{Call: _e.mock.On("Create", product)}
+func (_e *MockProductRepository_Expecter) Create(ctx interface{}, product interface{}) *MockProductRepository_Create_Call {
+	return &MockProductRepository_Create_Call{Call: _e.mock.On("Create", ctx, product)}
 }
 
-func (_c *MockProductRepository_Create_Call) Run(run func(product *Product)) *MockProductRepository_Create_Call {
+func (_c *MockProductRepository_Create_Call) Run(run func(ctx context.Context, product *Product)) *MockProductRepository_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *Product
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*Product)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *Product
+		if args[1] != nil {
+			arg1 = args[1].(*Product)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -132,14 +236,14 @@ func (_c *MockProductRepository_Create_Call) Return(err error) *MockProductRepos
 	return _c
 }
 
-func (_c *MockProductRepository_Create_Call) RunAndReturn(run func(product *Product) error) *MockProductRepository_Create_Call {
+func (_c *MockProductRepository_Create_Call) RunAndReturn(run func(ctx context.Context, product *Product) error) *MockProductRepository_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // FindById provides a mock function for the type MockProductRepository
-func (_mock *MockProductRepository) FindById(id int) (*Product, error) {
-	ret := _mock.Called(id)
+func (_mock *MockProductRepository) FindById(ctx context.Context, id int) (*Product, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FindById")
@@ -147,18 +251,18 @@ func (_mock *MockProductRepository) FindById(id int) (*Product, error) {
 
 	var r0 *Product
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int) (*Product, error)); ok {
-		return returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (*Product, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int) *Product); ok {
-		r0 = returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) *Product); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*Product)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
-		r1 = returnFunc(id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -171,19 +275,25 @@ type MockProductRepository_FindById_Call struct {
 }
 
 // FindById is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id int
-func (_e *MockProductRepository_Expecter) FindById(id interface{}) *MockProductRepository_FindById_Call {
-	return &MockProductRepository_FindById_Call{Call: _e.mock.On("FindById", id)}
+func (_e *MockProductRepository_Expecter) FindById(ctx interface{}, id interface{}) *MockProductRepository_FindById_Call {
+	return &MockProductRepository_FindById_Call{Call: _e.mock.On("FindById", ctx, id)}
 }
 
-func (_c *MockProductRepository_FindById_Call) Run(run func(id int)) *MockProductRepository_FindById_Call {
+func (_c *MockProductRepository_FindById_Call) Run(run func(ctx context.Context, id int)) *MockProductRepository_FindById_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(int)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -194,14 +304,14 @@ func (_c *MockProductRepository_FindById_Call) Return(product *Product, err erro
 	return _c
 }
 
-func (_c *MockProductRepository_FindById_Call) RunAndReturn(run func(id int) (*Product, error)) *MockProductRepository_FindById_Call {
+func (_c *MockProductRepository_FindById_Call) RunAndReturn(run func(ctx context.Context, id int) (*Product, error)) *MockProductRepository_FindById_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetAll provides a mock function for the type MockProductRepository
-func (_mock *MockProductRepository) GetAll() ([]*Product, error) {
-	ret := _mock.Called()
+func (_mock *MockProductRepository) GetAll(ctx context.Context) ([]*Product, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAll")
@@ -209,18 +319,18 @@ func (_mock *MockProductRepository) GetAll() ([]*Product, error) {
 
 	var r0 []*Product
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func() ([]*Product, error)); ok {
-		return returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*Product, error)); ok {
+		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func() []*Product); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*Product); ok {
+		r0 = returnFunc(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*Product)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func() error); ok {
-		r1 = returnFunc()
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -233,13 +343,20 @@ type MockProductRepository_GetAll_Call struct {
 }
 
 // GetAll is a helper method to define mock.On call
-func (_e *MockProductRepository_Expecter) GetAll() *MockProductRepository_GetAll_Call {
-	return &MockProductRepository_GetAll_Call{Call: _e.mock.On("GetAll")}
+//   - ctx context.Context
+func (_e *MockProductRepository_Expecter) GetAll(ctx interface{}) *MockProductRepository_GetAll_Call {
+	return &MockProductRepository_GetAll_Call{Call: _e.mock.On("GetAll", ctx)}
 }
 
-func (_c *MockProductRepository_GetAll_Call) Run(run func()) *MockProductRepository_GetAll_Call {
+func (_c *MockProductRepository_GetAll_Call) Run(run func(ctx context.Context)) *MockProductRepository_GetAll_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
 	})
 	return _c
 }
@@ -249,22 +366,79 @@ func (_c *MockProductRepository_GetAll_Call) Return(products []*Product, err err
 	return _c
 }
 
-func (_c *MockProductRepository_GetAll_Call) RunAndReturn(run func() ([]*Product, error)) *MockProductRepository_GetAll_Call {
+func (_c *MockProductRepository_GetAll_Call) RunAndReturn(run func(ctx context.Context) ([]*Product, error)) *MockProductRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Transaction provides a mock function for the type MockProductRepository
+func (_mock *MockProductRepository) Transaction(ctx context.Context, fn func(repo ProductRepository) error) error {
+	ret := _mock.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transaction")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func(repo ProductRepository) error) error); ok {
+		r0 = returnFunc(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockProductRepository_Transaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Transaction'
+type MockProductRepository_Transaction_Call struct {
+	*mock.Call
+}
+
+// Transaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(repo ProductRepository) error
+func (_e *MockProductRepository_Expecter) Transaction(ctx interface{}, fn interface{}) *MockProductRepository_Transaction_Call {
+	return &MockProductRepository_Transaction_Call{Call: _e.mock.On("Transaction", ctx, fn)}
+}
+
+func (_c *MockProductRepository_Transaction_Call) Run(run func(ctx context.Context, fn func(repo ProductRepository) error)) *MockProductRepository_Transaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 func(repo ProductRepository) error
+		if args[1] != nil {
+			arg1 = args[1].(func(repo ProductRepository) error)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepository_Transaction_Call) Return(err error) *MockProductRepository_Transaction_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockProductRepository_Transaction_Call) RunAndReturn(run func(ctx context.Context, fn func(repo ProductRepository) error) error) *MockProductRepository_Transaction_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Update provides a mock function for the type MockProductRepository
-func (_mock *MockProductRepository) Update(product *Product) error {
-	ret := _mock.Called(product)
+func (_mock *MockProductRepository) Update(ctx context.Context, product *Product) error {
+	ret := _mock.Called(ctx, product)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(*Product) error); ok {
-		r0 = returnFunc(product)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *Product) error); ok {
+		r0 = returnFunc(ctx, product)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -277,19 +451,25 @@ type MockProductRepository_Update_Call struct {
 }
 
 // Update is a helper method to define mock.On call
+//   - ctx context.Context
 //   - product *Product
-func (_e *MockProductRepository_Expecter) Update(product interface{}) *MockProductRepository_Update_Call {
-	return &MockProductRepository_Update_Call{Call: _e.mock.On("Update", product)}
+func (_e *MockProductRepository_Expecter) Update(ctx interface{}, product interface{}) *MockProductRepository_Update_Call {
+	return &MockProductRepository_Update_Call{Call: _e.mock.On("Update", ctx, product)}
 }
 
-func (_c *MockProductRepository_Update_Call) Run(run func(product *Product)) *MockProductRepository_Update_Call {
+func (_c *MockProductRepository_Update_Call) Run(run func(ctx context.Context, product *Product)) *MockProductRepository_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *Product
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*Product)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *Product
+		if args[1] != nil {
+			arg1 = args[1].(*Product)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -300,7 +480,2009 @@ func (_c *MockProductRepository_Update_Call) Return(err error) *MockProductRepos
 	return _c
 }
 
-func (_c *MockProductRepository_Update_Call) RunAndReturn(run func(product *Product) error) *MockProductRepository_Update_Call {
+func (_c *MockProductRepository_Update_Call) RunAndReturn(run func(ctx context.Context, product *Product) error) *MockProductRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockImportProfileRepository creates a new instance of MockImportProfileRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockImportProfileRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockImportProfileRepository {
+	mock := &MockImportProfileRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockImportProfileRepository is an autogenerated mock type for the ImportProfileRepository type
+type MockImportProfileRepository struct {
+	mock.Mock
+}
+
+type MockImportProfileRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockImportProfileRepository) EXPECT() *MockImportProfileRepository_Expecter {
+	return &MockImportProfileRepository_Expecter{mock: &_m.Mock}
+}
+
+// FindByName provides a mock function for the type MockImportProfileRepository
+func (_mock *MockImportProfileRepository) FindByName(name string) (*ImportProfile, error) {
+	ret := _mock.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByName")
+	}
+
+	var r0 *ImportProfile
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*ImportProfile, error)); ok {
+		return returnFunc(name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *ImportProfile); ok {
+		r0 = returnFunc(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ImportProfile)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockImportProfileRepository_FindByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByName'
+type MockImportProfileRepository_FindByName_Call struct {
+	*mock.Call
+}
+
+// FindByName is a helper method to define mock.On call
+//   - name string
+func (_e *MockImportProfileRepository_Expecter) FindByName(name interface{}) *MockImportProfileRepository_FindByName_Call {
+	return &MockImportProfileRepository_FindByName_Call{Call: _e.mock.On("FindByName", name)}
+}
+
+func (_c *MockImportProfileRepository_FindByName_Call) Run(run func(name string)) *MockImportProfileRepository_FindByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockImportProfileRepository_FindByName_Call) Return(importProfile *ImportProfile, err error) *MockImportProfileRepository_FindByName_Call {
+	_c.Call.Return(importProfile, err)
+	return _c
+}
+
+func (_c *MockImportProfileRepository_FindByName_Call) RunAndReturn(run func(name string) (*ImportProfile, error)) *MockImportProfileRepository_FindByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function for the type MockImportProfileRepository
+func (_mock *MockImportProfileRepository) GetAll() ([]*ImportProfile, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*ImportProfile
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]*ImportProfile, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []*ImportProfile); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*ImportProfile)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockImportProfileRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type MockImportProfileRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+func (_e *MockImportProfileRepository_Expecter) GetAll() *MockImportProfileRepository_GetAll_Call {
+	return &MockImportProfileRepository_GetAll_Call{Call: _e.mock.On("GetAll")}
+}
+
+func (_c *MockImportProfileRepository_GetAll_Call) Run(run func()) *MockImportProfileRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockImportProfileRepository_GetAll_Call) Return(importProfiles []*ImportProfile, err error) *MockImportProfileRepository_GetAll_Call {
+	_c.Call.Return(importProfiles, err)
+	return _c
+}
+
+func (_c *MockImportProfileRepository_GetAll_Call) RunAndReturn(run func() ([]*ImportProfile, error)) *MockImportProfileRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type MockImportProfileRepository
+func (_mock *MockImportProfileRepository) Save(profile *ImportProfile) error {
+	ret := _mock.Called(profile)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*ImportProfile) error); ok {
+		r0 = returnFunc(profile)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockImportProfileRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockImportProfileRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - profile *ImportProfile
+func (_e *MockImportProfileRepository_Expecter) Save(profile interface{}) *MockImportProfileRepository_Save_Call {
+	return &MockImportProfileRepository_Save_Call{Call: _e.mock.On("Save", profile)}
+}
+
+func (_c *MockImportProfileRepository_Save_Call) Run(run func(profile *ImportProfile)) *MockImportProfileRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *ImportProfile
+		if args[0] != nil {
+			arg0 = args[0].(*ImportProfile)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockImportProfileRepository_Save_Call) Return(err error) *MockImportProfileRepository_Save_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockImportProfileRepository_Save_Call) RunAndReturn(run func(profile *ImportProfile) error) *MockImportProfileRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockExchangeRateRepository creates a new instance of MockExchangeRateRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExchangeRateRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExchangeRateRepository {
+	mock := &MockExchangeRateRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockExchangeRateRepository is an autogenerated mock type for the ExchangeRateRepository type
+type MockExchangeRateRepository struct {
+	mock.Mock
+}
+
+type MockExchangeRateRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExchangeRateRepository) EXPECT() *MockExchangeRateRepository_Expecter {
+	return &MockExchangeRateRepository_Expecter{mock: &_m.Mock}
+}
+
+// FindByBase provides a mock function for the type MockExchangeRateRepository
+func (_mock *MockExchangeRateRepository) FindByBase(base string) (map[string]float64, error) {
+	ret := _mock.Called(base)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByBase")
+	}
+
+	var r0 map[string]float64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (map[string]float64, error)); ok {
+		return returnFunc(base)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) map[string]float64); ok {
+		r0 = returnFunc(base)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]float64)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(base)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockExchangeRateRepository_FindByBase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByBase'
+type MockExchangeRateRepository_FindByBase_Call struct {
+	*mock.Call
+}
+
+// FindByBase is a helper method to define mock.On call
+//   - base string
+func (_e *MockExchangeRateRepository_Expecter) FindByBase(base interface{}) *MockExchangeRateRepository_FindByBase_Call {
+	return &MockExchangeRateRepository_FindByBase_Call{Call: _e.mock.On("FindByBase", base)}
+}
+
+func (_c *MockExchangeRateRepository_FindByBase_Call) Run(run func(base string)) *MockExchangeRateRepository_FindByBase_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExchangeRateRepository_FindByBase_Call) Return(stringToFloat64 map[string]float64, err error) *MockExchangeRateRepository_FindByBase_Call {
+	_c.Call.Return(stringToFloat64, err)
+	return _c
+}
+
+func (_c *MockExchangeRateRepository_FindByBase_Call) RunAndReturn(run func(base string) (map[string]float64, error)) *MockExchangeRateRepository_FindByBase_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function for the type MockExchangeRateRepository
+func (_mock *MockExchangeRateRepository) GetAll() ([]*ExchangeRate, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 []*ExchangeRate
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]*ExchangeRate, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []*ExchangeRate); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*ExchangeRate)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockExchangeRateRepository_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type MockExchangeRateRepository_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+func (_e *MockExchangeRateRepository_Expecter) GetAll() *MockExchangeRateRepository_GetAll_Call {
+	return &MockExchangeRateRepository_GetAll_Call{Call: _e.mock.On("GetAll")}
+}
+
+func (_c *MockExchangeRateRepository_GetAll_Call) Run(run func()) *MockExchangeRateRepository_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockExchangeRateRepository_GetAll_Call) Return(exchangeRates []*ExchangeRate, err error) *MockExchangeRateRepository_GetAll_Call {
+	_c.Call.Return(exchangeRates, err)
+	return _c
+}
+
+func (_c *MockExchangeRateRepository_GetAll_Call) RunAndReturn(run func() ([]*ExchangeRate, error)) *MockExchangeRateRepository_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type MockExchangeRateRepository
+func (_mock *MockExchangeRateRepository) Save(rate *ExchangeRate) error {
+	ret := _mock.Called(rate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*ExchangeRate) error); ok {
+		r0 = returnFunc(rate)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockExchangeRateRepository_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockExchangeRateRepository_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - rate *ExchangeRate
+func (_e *MockExchangeRateRepository_Expecter) Save(rate interface{}) *MockExchangeRateRepository_Save_Call {
+	return &MockExchangeRateRepository_Save_Call{Call: _e.mock.On("Save", rate)}
+}
+
+func (_c *MockExchangeRateRepository_Save_Call) Run(run func(rate *ExchangeRate)) *MockExchangeRateRepository_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *ExchangeRate
+		if args[0] != nil {
+			arg0 = args[0].(*ExchangeRate)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExchangeRateRepository_Save_Call) Return(err error) *MockExchangeRateRepository_Save_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockExchangeRateRepository_Save_Call) RunAndReturn(run func(rate *ExchangeRate) error) *MockExchangeRateRepository_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockImportJobRepository creates a new instance of MockImportJobRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockImportJobRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockImportJobRepository {
+	mock := &MockImportJobRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockImportJobRepository is an autogenerated mock type for the ImportJobRepository type
+type MockImportJobRepository struct {
+	mock.Mock
+}
+
+type MockImportJobRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockImportJobRepository) EXPECT() *MockImportJobRepository_Expecter {
+	return &MockImportJobRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockImportJobRepository
+func (_mock *MockImportJobRepository) Create(job *ImportJob) error {
+	ret := _mock.Called(job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*ImportJob) error); ok {
+		r0 = returnFunc(job)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockImportJobRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockImportJobRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - job *ImportJob
+func (_e *MockImportJobRepository_Expecter) Create(job interface{}) *MockImportJobRepository_Create_Call {
+	return &MockImportJobRepository_Create_Call{Call: _e.mock.On("Create", job)}
+}
+
+func (_c *MockImportJobRepository_Create_Call) Run(run func(job *ImportJob)) *MockImportJobRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *ImportJob
+		if args[0] != nil {
+			arg0 = args[0].(*ImportJob)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockImportJobRepository_Create_Call) Return(err error) *MockImportJobRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockImportJobRepository_Create_Call) RunAndReturn(run func(job *ImportJob) error) *MockImportJobRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByID provides a mock function for the type MockImportJobRepository
+func (_mock *MockImportJobRepository) FindByID(id string) (*ImportJob, error) {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 *ImportJob
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*ImportJob, error)); ok {
+		return returnFunc(id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *ImportJob); ok {
+		r0 = returnFunc(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ImportJob)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockImportJobRepository_FindByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByID'
+type MockImportJobRepository_FindByID_Call struct {
+	*mock.Call
+}
+
+// FindByID is a helper method to define mock.On call
+//   - id string
+func (_e *MockImportJobRepository_Expecter) FindByID(id interface{}) *MockImportJobRepository_FindByID_Call {
+	return &MockImportJobRepository_FindByID_Call{Call: _e.mock.On("FindByID", id)}
+}
+
+func (_c *MockImportJobRepository_FindByID_Call) Run(run func(id string)) *MockImportJobRepository_FindByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockImportJobRepository_FindByID_Call) Return(importJob *ImportJob, err error) *MockImportJobRepository_FindByID_Call {
+	_c.Call.Return(importJob, err)
+	return _c
+}
+
+func (_c *MockImportJobRepository_FindByID_Call) RunAndReturn(run func(id string) (*ImportJob, error)) *MockImportJobRepository_FindByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockImportJobRepository
+func (_mock *MockImportJobRepository) List(status JobStatus, limit int) ([]*ImportJob, error) {
+	ret := _mock.Called(status, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*ImportJob
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(JobStatus, int) ([]*ImportJob, error)); ok {
+		return returnFunc(status, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(JobStatus, int) []*ImportJob); ok {
+		r0 = returnFunc(status, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*ImportJob)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(JobStatus, int) error); ok {
+		r1 = returnFunc(status, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockImportJobRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockImportJobRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - status JobStatus
+//   - limit int
+func (_e *MockImportJobRepository_Expecter) List(status interface{}, limit interface{}) *MockImportJobRepository_List_Call {
+	return &MockImportJobRepository_List_Call{Call: _e.mock.On("List", status, limit)}
+}
+
+func (_c *MockImportJobRepository_List_Call) Run(run func(status JobStatus, limit int)) *MockImportJobRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 JobStatus
+		if args[0] != nil {
+			arg0 = args[0].(JobStatus)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockImportJobRepository_List_Call) Return(importJobs []*ImportJob, err error) *MockImportJobRepository_List_Call {
+	_c.Call.Return(importJobs, err)
+	return _c
+}
+
+func (_c *MockImportJobRepository_List_Call) RunAndReturn(run func(status JobStatus, limit int) ([]*ImportJob, error)) *MockImportJobRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockImportJobRepository
+func (_mock *MockImportJobRepository) Update(job *ImportJob) error {
+	ret := _mock.Called(job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*ImportJob) error); ok {
+		r0 = returnFunc(job)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockImportJobRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockImportJobRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - job *ImportJob
+func (_e *MockImportJobRepository_Expecter) Update(job interface{}) *MockImportJobRepository_Update_Call {
+	return &MockImportJobRepository_Update_Call{Call: _e.mock.On("Update", job)}
+}
+
+func (_c *MockImportJobRepository_Update_Call) Run(run func(job *ImportJob)) *MockImportJobRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *ImportJob
+		if args[0] != nil {
+			arg0 = args[0].(*ImportJob)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockImportJobRepository_Update_Call) Return(err error) *MockImportJobRepository_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockImportJobRepository_Update_Call) RunAndReturn(run func(job *ImportJob) error) *MockImportJobRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCSVProcessorUsecase creates a new instance of MockCSVProcessorUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCSVProcessorUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCSVProcessorUsecase {
+	mock := &MockCSVProcessorUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCSVProcessorUsecase is an autogenerated mock type for the CSVProcessorUsecase type
+type MockCSVProcessorUsecase struct {
+	mock.Mock
+}
+
+type MockCSVProcessorUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCSVProcessorUsecase) EXPECT() *MockCSVProcessorUsecase_Expecter {
+	return &MockCSVProcessorUsecase_Expecter{mock: &_m.Mock}
+}
+
+// ProcessCSVFiles provides a mock function for the type MockCSVProcessorUsecase
+func (_mock *MockCSVProcessorUsecase) ProcessCSVFiles(ctx context.Context, filePaths []string, options ImportOptions, progressChan chan<- *ProgressUpdate) (*FinalResult, error) {
+	ret := _mock.Called(ctx, filePaths, options, progressChan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessCSVFiles")
+	}
+
+	var r0 *FinalResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, ImportOptions, chan<- *ProgressUpdate) (*FinalResult, error)); ok {
+		return returnFunc(ctx, filePaths, options, progressChan)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, ImportOptions, chan<- *ProgressUpdate) *FinalResult); ok {
+		r0 = returnFunc(ctx, filePaths, options, progressChan)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*FinalResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, ImportOptions, chan<- *ProgressUpdate) error); ok {
+		r1 = returnFunc(ctx, filePaths, options, progressChan)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCSVProcessorUsecase_ProcessCSVFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessCSVFiles'
+type MockCSVProcessorUsecase_ProcessCSVFiles_Call struct {
+	*mock.Call
+}
+
+// ProcessCSVFiles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filePaths []string
+//   - options ImportOptions
+//   - progressChan chan<- *ProgressUpdate
+func (_e *MockCSVProcessorUsecase_Expecter) ProcessCSVFiles(ctx interface{}, filePaths interface{}, options interface{}, progressChan interface{}) *MockCSVProcessorUsecase_ProcessCSVFiles_Call {
+	return &MockCSVProcessorUsecase_ProcessCSVFiles_Call{Call: _e.mock.On("ProcessCSVFiles", ctx, filePaths, options, progressChan)}
+}
+
+func (_c *MockCSVProcessorUsecase_ProcessCSVFiles_Call) Run(run func(ctx context.Context, filePaths []string, options ImportOptions, progressChan chan<- *ProgressUpdate)) *MockCSVProcessorUsecase_ProcessCSVFiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 ImportOptions
+		if args[2] != nil {
+			arg2 = args[2].(ImportOptions)
+		}
+		var arg3 chan<- *ProgressUpdate
+		if args[3] != nil {
+			arg3 = args[3].(chan<- *ProgressUpdate)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCSVProcessorUsecase_ProcessCSVFiles_Call) Return(finalResult *FinalResult, err error) *MockCSVProcessorUsecase_ProcessCSVFiles_Call {
+	_c.Call.Return(finalResult, err)
+	return _c
+}
+
+func (_c *MockCSVProcessorUsecase_ProcessCSVFiles_Call) RunAndReturn(run func(ctx context.Context, filePaths []string, options ImportOptions, progressChan chan<- *ProgressUpdate) (*FinalResult, error)) *MockCSVProcessorUsecase_ProcessCSVFiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockImportJobUsecase creates a new instance of MockImportJobUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockImportJobUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockImportJobUsecase {
+	mock := &MockImportJobUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockImportJobUsecase is an autogenerated mock type for the ImportJobUsecase type
+type MockImportJobUsecase struct {
+	mock.Mock
+}
+
+type MockImportJobUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockImportJobUsecase) EXPECT() *MockImportJobUsecase_Expecter {
+	return &MockImportJobUsecase_Expecter{mock: &_m.Mock}
+}
+
+// Control provides a mock function for the type MockImportJobUsecase
+func (_mock *MockImportJobUsecase) Control(id string, action JobAction) error {
+	ret := _mock.Called(id, action)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Control")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, JobAction) error); ok {
+		r0 = returnFunc(id, action)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockImportJobUsecase_Control_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Control'
+type MockImportJobUsecase_Control_Call struct {
+	*mock.Call
+}
+
+// Control is a helper method to define mock.On call
+//   - id string
+//   - action JobAction
+func (_e *MockImportJobUsecase_Expecter) Control(id interface{}, action interface{}) *MockImportJobUsecase_Control_Call {
+	return &MockImportJobUsecase_Control_Call{Call: _e.mock.On("Control", id, action)}
+}
+
+func (_c *MockImportJobUsecase_Control_Call) Run(run func(id string, action JobAction)) *MockImportJobUsecase_Control_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 JobAction
+		if args[1] != nil {
+			arg1 = args[1].(JobAction)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockImportJobUsecase_Control_Call) Return(err error) *MockImportJobUsecase_Control_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockImportJobUsecase_Control_Call) RunAndReturn(run func(id string, action JobAction) error) *MockImportJobUsecase_Control_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Enqueue provides a mock function for the type MockImportJobUsecase
+func (_mock *MockImportJobUsecase) Enqueue(filePaths []string, options ImportOptions) (*ImportJob, error) {
+	ret := _mock.Called(filePaths, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enqueue")
+	}
+
+	var r0 *ImportJob
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]string, ImportOptions) (*ImportJob, error)); ok {
+		return returnFunc(filePaths, options)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]string, ImportOptions) *ImportJob); ok {
+		r0 = returnFunc(filePaths, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ImportJob)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func([]string, ImportOptions) error); ok {
+		r1 = returnFunc(filePaths, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockImportJobUsecase_Enqueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enqueue'
+type MockImportJobUsecase_Enqueue_Call struct {
+	*mock.Call
+}
+
+// Enqueue is a helper method to define mock.On call
+//   - filePaths []string
+//   - options ImportOptions
+func (_e *MockImportJobUsecase_Expecter) Enqueue(filePaths interface{}, options interface{}) *MockImportJobUsecase_Enqueue_Call {
+	return &MockImportJobUsecase_Enqueue_Call{Call: _e.mock.On("Enqueue", filePaths, options)}
+}
+
+func (_c *MockImportJobUsecase_Enqueue_Call) Run(run func(filePaths []string, options ImportOptions)) *MockImportJobUsecase_Enqueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []string
+		if args[0] != nil {
+			arg0 = args[0].([]string)
+		}
+		var arg1 ImportOptions
+		if args[1] != nil {
+			arg1 = args[1].(ImportOptions)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockImportJobUsecase_Enqueue_Call) Return(importJob *ImportJob, err error) *MockImportJobUsecase_Enqueue_Call {
+	_c.Call.Return(importJob, err)
+	return _c
+}
+
+func (_c *MockImportJobUsecase_Enqueue_Call) RunAndReturn(run func(filePaths []string, options ImportOptions) (*ImportJob, error)) *MockImportJobUsecase_Enqueue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetJob provides a mock function for the type MockImportJobUsecase
+func (_mock *MockImportJobUsecase) GetJob(id string) (*ImportJob, error) {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJob")
+	}
+
+	var r0 *ImportJob
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*ImportJob, error)); ok {
+		return returnFunc(id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *ImportJob); ok {
+		r0 = returnFunc(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ImportJob)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockImportJobUsecase_GetJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJob'
+type MockImportJobUsecase_GetJob_Call struct {
+	*mock.Call
+}
+
+// GetJob is a helper method to define mock.On call
+//   - id string
+func (_e *MockImportJobUsecase_Expecter) GetJob(id interface{}) *MockImportJobUsecase_GetJob_Call {
+	return &MockImportJobUsecase_GetJob_Call{Call: _e.mock.On("GetJob", id)}
+}
+
+func (_c *MockImportJobUsecase_GetJob_Call) Run(run func(id string)) *MockImportJobUsecase_GetJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockImportJobUsecase_GetJob_Call) Return(importJob *ImportJob, err error) *MockImportJobUsecase_GetJob_Call {
+	_c.Call.Return(importJob, err)
+	return _c
+}
+
+func (_c *MockImportJobUsecase_GetJob_Call) RunAndReturn(run func(id string) (*ImportJob, error)) *MockImportJobUsecase_GetJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListJobs provides a mock function for the type MockImportJobUsecase
+func (_mock *MockImportJobUsecase) ListJobs(status JobStatus, limit int) ([]*ImportJob, error) {
+	ret := _mock.Called(status, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListJobs")
+	}
+
+	var r0 []*ImportJob
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(JobStatus, int) ([]*ImportJob, error)); ok {
+		return returnFunc(status, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(JobStatus, int) []*ImportJob); ok {
+		r0 = returnFunc(status, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*ImportJob)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(JobStatus, int) error); ok {
+		r1 = returnFunc(status, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockImportJobUsecase_ListJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListJobs'
+type MockImportJobUsecase_ListJobs_Call struct {
+	*mock.Call
+}
+
+// ListJobs is a helper method to define mock.On call
+//   - status JobStatus
+//   - limit int
+func (_e *MockImportJobUsecase_Expecter) ListJobs(status interface{}, limit interface{}) *MockImportJobUsecase_ListJobs_Call {
+	return &MockImportJobUsecase_ListJobs_Call{Call: _e.mock.On("ListJobs", status, limit)}
+}
+
+func (_c *MockImportJobUsecase_ListJobs_Call) Run(run func(status JobStatus, limit int)) *MockImportJobUsecase_ListJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 JobStatus
+		if args[0] != nil {
+			arg0 = args[0].(JobStatus)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockImportJobUsecase_ListJobs_Call) Return(importJobs []*ImportJob, err error) *MockImportJobUsecase_ListJobs_Call {
+	_c.Call.Return(importJobs, err)
+	return _c
+}
+
+func (_c *MockImportJobUsecase_ListJobs_Call) RunAndReturn(run func(status JobStatus, limit int) ([]*ImportJob, error)) *MockImportJobUsecase_ListJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Shutdown provides a mock function for the type MockImportJobUsecase
+func (_mock *MockImportJobUsecase) Shutdown(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Shutdown")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockImportJobUsecase_Shutdown_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Shutdown'
+type MockImportJobUsecase_Shutdown_Call struct {
+	*mock.Call
+}
+
+// Shutdown is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockImportJobUsecase_Expecter) Shutdown(ctx interface{}) *MockImportJobUsecase_Shutdown_Call {
+	return &MockImportJobUsecase_Shutdown_Call{Call: _e.mock.On("Shutdown", ctx)}
+}
+
+func (_c *MockImportJobUsecase_Shutdown_Call) Run(run func(ctx context.Context)) *MockImportJobUsecase_Shutdown_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockImportJobUsecase_Shutdown_Call) Return(err error) *MockImportJobUsecase_Shutdown_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockImportJobUsecase_Shutdown_Call) RunAndReturn(run func(ctx context.Context) error) *MockImportJobUsecase_Shutdown_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Subscribe provides a mock function for the type MockImportJobUsecase
+func (_mock *MockImportJobUsecase) Subscribe(id string) (<-chan *JobEvent, func(), error) {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 <-chan *JobEvent
+	var r1 func()
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(string) (<-chan *JobEvent, func(), error)); ok {
+		return returnFunc(id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) <-chan *JobEvent); ok {
+		r0 = returnFunc(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *JobEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) func()); ok {
+		r1 = returnFunc(id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func())
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(string) error); ok {
+		r2 = returnFunc(id)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockImportJobUsecase_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type MockImportJobUsecase_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - id string
+func (_e *MockImportJobUsecase_Expecter) Subscribe(id interface{}) *MockImportJobUsecase_Subscribe_Call {
+	return &MockImportJobUsecase_Subscribe_Call{Call: _e.mock.On("Subscribe", id)}
+}
+
+func (_c *MockImportJobUsecase_Subscribe_Call) Run(run func(id string)) *MockImportJobUsecase_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockImportJobUsecase_Subscribe_Call) Return(jobEventCh <-chan *JobEvent, fn func(), err error) *MockImportJobUsecase_Subscribe_Call {
+	_c.Call.Return(jobEventCh, fn, err)
+	return _c
+}
+
+func (_c *MockImportJobUsecase_Subscribe_Call) RunAndReturn(run func(id string) (<-chan *JobEvent, func(), error)) *MockImportJobUsecase_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPathResolver creates a new instance of MockPathResolver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPathResolver(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPathResolver {
+	mock := &MockPathResolver{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockPathResolver is an autogenerated mock type for the PathResolver type
+type MockPathResolver struct {
+	mock.Mock
+}
+
+type MockPathResolver_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPathResolver) EXPECT() *MockPathResolver_Expecter {
+	return &MockPathResolver_Expecter{mock: &_m.Mock}
+}
+
+// Expand provides a mock function for the type MockPathResolver
+func (_mock *MockPathResolver) Expand(path string, recursive bool) ([]string, error) {
+	ret := _mock.Called(path, recursive)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Expand")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, bool) ([]string, error)); ok {
+		return returnFunc(path, recursive)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, bool) []string); ok {
+		r0 = returnFunc(path, recursive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, bool) error); ok {
+		r1 = returnFunc(path, recursive)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPathResolver_Expand_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Expand'
+type MockPathResolver_Expand_Call struct {
+	*mock.Call
+}
+
+// Expand is a helper method to define mock.On call
+//   - path string
+//   - recursive bool
+func (_e *MockPathResolver_Expecter) Expand(path interface{}, recursive interface{}) *MockPathResolver_Expand_Call {
+	return &MockPathResolver_Expand_Call{Call: _e.mock.On("Expand", path, recursive)}
+}
+
+func (_c *MockPathResolver_Expand_Call) Run(run func(path string, recursive bool)) *MockPathResolver_Expand_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPathResolver_Expand_Call) Return(strings []string, err error) *MockPathResolver_Expand_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockPathResolver_Expand_Call) RunAndReturn(run func(path string, recursive bool) ([]string, error)) *MockPathResolver_Expand_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Resolve provides a mock function for the type MockPathResolver
+func (_mock *MockPathResolver) Resolve(path string) (string, error) {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resolve")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return returnFunc(path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) string); ok {
+		r0 = returnFunc(path)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPathResolver_Resolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resolve'
+type MockPathResolver_Resolve_Call struct {
+	*mock.Call
+}
+
+// Resolve is a helper method to define mock.On call
+//   - path string
+func (_e *MockPathResolver_Expecter) Resolve(path interface{}) *MockPathResolver_Resolve_Call {
+	return &MockPathResolver_Resolve_Call{Call: _e.mock.On("Resolve", path)}
+}
+
+func (_c *MockPathResolver_Resolve_Call) Run(run func(path string)) *MockPathResolver_Resolve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPathResolver_Resolve_Call) Return(s string, err error) *MockPathResolver_Resolve_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockPathResolver_Resolve_Call) RunAndReturn(run func(path string) (string, error)) *MockPathResolver_Resolve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockFileStore creates a new instance of MockFileStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFileStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFileStore {
+	mock := &MockFileStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockFileStore is an autogenerated mock type for the FileStore type
+type MockFileStore struct {
+	mock.Mock
+}
+
+type MockFileStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFileStore) EXPECT() *MockFileStore_Expecter {
+	return &MockFileStore_Expecter{mock: &_m.Mock}
+}
+
+// Remove provides a mock function for the type MockFileStore
+func (_mock *MockFileStore) Remove(file *StoredFile) error {
+	ret := _mock.Called(file)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remove")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*StoredFile) error); ok {
+		r0 = returnFunc(file)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFileStore_Remove_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remove'
+type MockFileStore_Remove_Call struct {
+	*mock.Call
+}
+
+// Remove is a helper method to define mock.On call
+//   - file *StoredFile
+func (_e *MockFileStore_Expecter) Remove(file interface{}) *MockFileStore_Remove_Call {
+	return &MockFileStore_Remove_Call{Call: _e.mock.On("Remove", file)}
+}
+
+func (_c *MockFileStore_Remove_Call) Run(run func(file *StoredFile)) *MockFileStore_Remove_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *StoredFile
+		if args[0] != nil {
+			arg0 = args[0].(*StoredFile)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileStore_Remove_Call) Return(err error) *MockFileStore_Remove_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFileStore_Remove_Call) RunAndReturn(run func(file *StoredFile) error) *MockFileStore_Remove_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type MockFileStore
+func (_mock *MockFileStore) Save(name string, r io.Reader) (*StoredFile, error) {
+	ret := _mock.Called(name, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 *StoredFile
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, io.Reader) (*StoredFile, error)); ok {
+		return returnFunc(name, r)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, io.Reader) *StoredFile); ok {
+		r0 = returnFunc(name, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*StoredFile)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, io.Reader) error); ok {
+		r1 = returnFunc(name, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFileStore_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockFileStore_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - name string
+//   - r io.Reader
+func (_e *MockFileStore_Expecter) Save(name interface{}, r interface{}) *MockFileStore_Save_Call {
+	return &MockFileStore_Save_Call{Call: _e.mock.On("Save", name, r)}
+}
+
+func (_c *MockFileStore_Save_Call) Run(run func(name string, r io.Reader)) *MockFileStore_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 io.Reader
+		if args[1] != nil {
+			arg1 = args[1].(io.Reader)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFileStore_Save_Call) Return(storedFile *StoredFile, err error) *MockFileStore_Save_Call {
+	_c.Call.Return(storedFile, err)
+	return _c
+}
+
+func (_c *MockFileStore_Save_Call) RunAndReturn(run func(name string, r io.Reader) (*StoredFile, error)) *MockFileStore_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRecordSource creates a new instance of MockRecordSource. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRecordSource(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRecordSource {
+	mock := &MockRecordSource{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRecordSource is an autogenerated mock type for the RecordSource type
+type MockRecordSource struct {
+	mock.Mock
+}
+
+type MockRecordSource_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRecordSource) EXPECT() *MockRecordSource_Expecter {
+	return &MockRecordSource_Expecter{mock: &_m.Mock}
+}
+
+// Close provides a mock function for the type MockRecordSource
+func (_mock *MockRecordSource) Close() error {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func() error); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRecordSource_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
+type MockRecordSource_Close_Call struct {
+	*mock.Call
+}
+
+// Close is a helper method to define mock.On call
+func (_e *MockRecordSource_Expecter) Close() *MockRecordSource_Close_Call {
+	return &MockRecordSource_Close_Call{Call: _e.mock.On("Close")}
+}
+
+func (_c *MockRecordSource_Close_Call) Run(run func()) *MockRecordSource_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRecordSource_Close_Call) Return(err error) *MockRecordSource_Close_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRecordSource_Close_Call) RunAndReturn(run func() error) *MockRecordSource_Close_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Next provides a mock function for the type MockRecordSource
+func (_mock *MockRecordSource) Next() (*CSVRecord, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Next")
+	}
+
+	var r0 *CSVRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() (*CSVRecord, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() *CSVRecord); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*CSVRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRecordSource_Next_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Next'
+type MockRecordSource_Next_Call struct {
+	*mock.Call
+}
+
+// Next is a helper method to define mock.On call
+func (_e *MockRecordSource_Expecter) Next() *MockRecordSource_Next_Call {
+	return &MockRecordSource_Next_Call{Call: _e.mock.On("Next")}
+}
+
+func (_c *MockRecordSource_Next_Call) Run(run func()) *MockRecordSource_Next_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRecordSource_Next_Call) Return(cSVRecord *CSVRecord, err error) *MockRecordSource_Next_Call {
+	_c.Call.Return(cSVRecord, err)
+	return _c
+}
+
+func (_c *MockRecordSource_Next_Call) RunAndReturn(run func() (*CSVRecord, error)) *MockRecordSource_Next_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Offset provides a mock function for the type MockRecordSource
+func (_mock *MockRecordSource) Offset() int64 {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Offset")
+	}
+
+	var r0 int64
+	if returnFunc, ok := ret.Get(0).(func() int64); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	return r0
+}
+
+// MockRecordSource_Offset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Offset'
+type MockRecordSource_Offset_Call struct {
+	*mock.Call
+}
+
+// Offset is a helper method to define mock.On call
+func (_e *MockRecordSource_Expecter) Offset() *MockRecordSource_Offset_Call {
+	return &MockRecordSource_Offset_Call{Call: _e.mock.On("Offset")}
+}
+
+func (_c *MockRecordSource_Offset_Call) Run(run func()) *MockRecordSource_Offset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRecordSource_Offset_Call) Return(n int64) *MockRecordSource_Offset_Call {
+	_c.Call.Return(n)
+	return _c
+}
+
+func (_c *MockRecordSource_Offset_Call) RunAndReturn(run func() int64) *MockRecordSource_Offset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Size provides a mock function for the type MockRecordSource
+func (_mock *MockRecordSource) Size() int64 {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Size")
+	}
+
+	var r0 int64
+	if returnFunc, ok := ret.Get(0).(func() int64); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	return r0
+}
+
+// MockRecordSource_Size_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Size'
+type MockRecordSource_Size_Call struct {
+	*mock.Call
+}
+
+// Size is a helper method to define mock.On call
+func (_e *MockRecordSource_Expecter) Size() *MockRecordSource_Size_Call {
+	return &MockRecordSource_Size_Call{Call: _e.mock.On("Size")}
+}
+
+func (_c *MockRecordSource_Size_Call) Run(run func()) *MockRecordSource_Size_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRecordSource_Size_Call) Return(n int64) *MockRecordSource_Size_Call {
+	_c.Call.Return(n)
+	return _c
+}
+
+func (_c *MockRecordSource_Size_Call) RunAndReturn(run func() int64) *MockRecordSource_Size_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockImportProfileUsecase creates a new instance of MockImportProfileUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockImportProfileUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockImportProfileUsecase {
+	mock := &MockImportProfileUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockImportProfileUsecase is an autogenerated mock type for the ImportProfileUsecase type
+type MockImportProfileUsecase struct {
+	mock.Mock
+}
+
+type MockImportProfileUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockImportProfileUsecase) EXPECT() *MockImportProfileUsecase_Expecter {
+	return &MockImportProfileUsecase_Expecter{mock: &_m.Mock}
+}
+
+// GetProfile provides a mock function for the type MockImportProfileUsecase
+func (_mock *MockImportProfileUsecase) GetProfile(name string) (*ImportProfile, error) {
+	ret := _mock.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProfile")
+	}
+
+	var r0 *ImportProfile
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*ImportProfile, error)); ok {
+		return returnFunc(name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *ImportProfile); ok {
+		r0 = returnFunc(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ImportProfile)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockImportProfileUsecase_GetProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProfile'
+type MockImportProfileUsecase_GetProfile_Call struct {
+	*mock.Call
+}
+
+// GetProfile is a helper method to define mock.On call
+//   - name string
+func (_e *MockImportProfileUsecase_Expecter) GetProfile(name interface{}) *MockImportProfileUsecase_GetProfile_Call {
+	return &MockImportProfileUsecase_GetProfile_Call{Call: _e.mock.On("GetProfile", name)}
+}
+
+func (_c *MockImportProfileUsecase_GetProfile_Call) Run(run func(name string)) *MockImportProfileUsecase_GetProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockImportProfileUsecase_GetProfile_Call) Return(importProfile *ImportProfile, err error) *MockImportProfileUsecase_GetProfile_Call {
+	_c.Call.Return(importProfile, err)
+	return _c
+}
+
+func (_c *MockImportProfileUsecase_GetProfile_Call) RunAndReturn(run func(name string) (*ImportProfile, error)) *MockImportProfileUsecase_GetProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListProfiles provides a mock function for the type MockImportProfileUsecase
+func (_mock *MockImportProfileUsecase) ListProfiles() ([]*ImportProfile, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListProfiles")
+	}
+
+	var r0 []*ImportProfile
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]*ImportProfile, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []*ImportProfile); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*ImportProfile)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockImportProfileUsecase_ListProfiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListProfiles'
+type MockImportProfileUsecase_ListProfiles_Call struct {
+	*mock.Call
+}
+
+// ListProfiles is a helper method to define mock.On call
+func (_e *MockImportProfileUsecase_Expecter) ListProfiles() *MockImportProfileUsecase_ListProfiles_Call {
+	return &MockImportProfileUsecase_ListProfiles_Call{Call: _e.mock.On("ListProfiles")}
+}
+
+func (_c *MockImportProfileUsecase_ListProfiles_Call) Run(run func()) *MockImportProfileUsecase_ListProfiles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockImportProfileUsecase_ListProfiles_Call) Return(importProfiles []*ImportProfile, err error) *MockImportProfileUsecase_ListProfiles_Call {
+	_c.Call.Return(importProfiles, err)
+	return _c
+}
+
+func (_c *MockImportProfileUsecase_ListProfiles_Call) RunAndReturn(run func() ([]*ImportProfile, error)) *MockImportProfileUsecase_ListProfiles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveProfile provides a mock function for the type MockImportProfileUsecase
+func (_mock *MockImportProfileUsecase) SaveProfile(profile *ImportProfile) error {
+	ret := _mock.Called(profile)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveProfile")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*ImportProfile) error); ok {
+		r0 = returnFunc(profile)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockImportProfileUsecase_SaveProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveProfile'
+type MockImportProfileUsecase_SaveProfile_Call struct {
+	*mock.Call
+}
+
+// SaveProfile is a helper method to define mock.On call
+//   - profile *ImportProfile
+func (_e *MockImportProfileUsecase_Expecter) SaveProfile(profile interface{}) *MockImportProfileUsecase_SaveProfile_Call {
+	return &MockImportProfileUsecase_SaveProfile_Call{Call: _e.mock.On("SaveProfile", profile)}
+}
+
+func (_c *MockImportProfileUsecase_SaveProfile_Call) Run(run func(profile *ImportProfile)) *MockImportProfileUsecase_SaveProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *ImportProfile
+		if args[0] != nil {
+			arg0 = args[0].(*ImportProfile)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockImportProfileUsecase_SaveProfile_Call) Return(err error) *MockImportProfileUsecase_SaveProfile_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockImportProfileUsecase_SaveProfile_Call) RunAndReturn(run func(profile *ImportProfile) error) *MockImportProfileUsecase_SaveProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockExchangeRateUsecase creates a new instance of MockExchangeRateUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExchangeRateUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExchangeRateUsecase {
+	mock := &MockExchangeRateUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockExchangeRateUsecase is an autogenerated mock type for the ExchangeRateUsecase type
+type MockExchangeRateUsecase struct {
+	mock.Mock
+}
+
+type MockExchangeRateUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExchangeRateUsecase) EXPECT() *MockExchangeRateUsecase_Expecter {
+	return &MockExchangeRateUsecase_Expecter{mock: &_m.Mock}
+}
+
+// ListRates provides a mock function for the type MockExchangeRateUsecase
+func (_mock *MockExchangeRateUsecase) ListRates() ([]*ExchangeRate, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRates")
+	}
+
+	var r0 []*ExchangeRate
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]*ExchangeRate, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []*ExchangeRate); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*ExchangeRate)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockExchangeRateUsecase_ListRates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRates'
+type MockExchangeRateUsecase_ListRates_Call struct {
+	*mock.Call
+}
+
+// ListRates is a helper method to define mock.On call
+func (_e *MockExchangeRateUsecase_Expecter) ListRates() *MockExchangeRateUsecase_ListRates_Call {
+	return &MockExchangeRateUsecase_ListRates_Call{Call: _e.mock.On("ListRates")}
+}
+
+func (_c *MockExchangeRateUsecase_ListRates_Call) Run(run func()) *MockExchangeRateUsecase_ListRates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockExchangeRateUsecase_ListRates_Call) Return(exchangeRates []*ExchangeRate, err error) *MockExchangeRateUsecase_ListRates_Call {
+	_c.Call.Return(exchangeRates, err)
+	return _c
+}
+
+func (_c *MockExchangeRateUsecase_ListRates_Call) RunAndReturn(run func() ([]*ExchangeRate, error)) *MockExchangeRateUsecase_ListRates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveRate provides a mock function for the type MockExchangeRateUsecase
+func (_mock *MockExchangeRateUsecase) SaveRate(rate *ExchangeRate) error {
+	ret := _mock.Called(rate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveRate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(*ExchangeRate) error); ok {
+		r0 = returnFunc(rate)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockExchangeRateUsecase_SaveRate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveRate'
+type MockExchangeRateUsecase_SaveRate_Call struct {
+	*mock.Call
+}
+
+// SaveRate is a helper method to define mock.On call
+//   - rate *ExchangeRate
+func (_e *MockExchangeRateUsecase_Expecter) SaveRate(rate interface{}) *MockExchangeRateUsecase_SaveRate_Call {
+	return &MockExchangeRateUsecase_SaveRate_Call{Call: _e.mock.On("SaveRate", rate)}
+}
+
+func (_c *MockExchangeRateUsecase_SaveRate_Call) Run(run func(rate *ExchangeRate)) *MockExchangeRateUsecase_SaveRate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *ExchangeRate
+		if args[0] != nil {
+			arg0 = args[0].(*ExchangeRate)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExchangeRateUsecase_SaveRate_Call) Return(err error) *MockExchangeRateUsecase_SaveRate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockExchangeRateUsecase_SaveRate_Call) RunAndReturn(run func(rate *ExchangeRate) error) *MockExchangeRateUsecase_SaveRate_Call {
 	_c.Call.Return(run)
 	return _c
 }
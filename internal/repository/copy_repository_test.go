@@ -0,0 +1,230 @@
+// ============================================
+// internal/repository/copy_repository_test.go
+// ============================================
+package repository
+
+import (
+	"context"
+	"data-processing/internal/domain"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestCopyRepository_BulkUpsert(t *testing.T) {
+	t.Run("success - empty products", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		repo := NewCopyRepository(db)
+
+		outcomes, err := repo.BulkUpsert(context.Background(), nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, outcomes)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("error - not a pgx connection", func(t *testing.T) {
+		db, _ := setupTestDB(t)
+		repo := NewCopyRepository(db)
+
+		products := []*domain.Product{{ID: 1, Name: "Product 1", CreatedBy: "test_user"}}
+
+		outcomes, err := repo.BulkUpsert(context.Background(), products)
+
+		assert.Error(t, err)
+		assert.Nil(t, outcomes)
+		assert.Contains(t, err.Error(), "requires a pgx connection")
+	})
+}
+
+// countTestProducts returns how many test rows are stored in products.
+func countTestProducts(t *testing.T, db *gorm.DB) int64 {
+	var count int64
+	require.NoError(t, db.Raw("SELECT count(*) FROM products WHERE id >= ?", testIDBase).Scan(&count).Error)
+	return count
+}
+
+func TestCopyRepository_Integration(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success - inserted then updated", func(t *testing.T) {
+		db := openTestDB(t)
+		repo := NewCopyRepository(db)
+
+		outcomes, err := repo.BulkUpsert(ctx, testProducts(2))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []domain.UpsertOutcome{
+			{ID: testIDBase, Inserted: true},
+			{ID: testIDBase + 1, Inserted: true},
+		}, outcomes)
+
+		products := testProducts(2)
+		products[0].Name = "Renamed"
+		outcomes, err = repo.BulkUpsert(ctx, products)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []domain.UpsertOutcome{
+			{ID: testIDBase, Inserted: false},
+			{ID: testIDBase + 1, Inserted: false},
+		}, outcomes)
+
+		product, err := repo.FindById(ctx, testIDBase)
+		require.NoError(t, err)
+		assert.Equal(t, "Renamed", product.Name)
+		assert.Equal(t, int64(2), countTestProducts(t, db))
+	})
+
+	t.Run("success - batches committed together", func(t *testing.T) {
+		db := openTestDB(t)
+		repo := NewCopyRepository(db)
+		products := testProducts(4)
+
+		err := repo.Transaction(ctx, func(tx domain.ProductRepository) error {
+			// The staging table of the first batch must be gone before the
+			// second creates its own in the same transaction.
+			if _, err := tx.BulkUpsert(ctx, products[:2]); err != nil {
+				return err
+			}
+			_, err := tx.BulkUpsert(ctx, products[2:])
+			return err
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), countTestProducts(t, db))
+	})
+
+	t.Run("success - failed batch only undoes itself", func(t *testing.T) {
+		db := openTestDB(t)
+		repo := NewCopyRepository(db)
+		products := testProducts(2)
+
+		err := repo.Transaction(ctx, func(tx domain.ProductRepository) error {
+			if _, err := tx.BulkUpsert(ctx, products[:1]); err != nil {
+				return err
+			}
+			// ON CONFLICT cannot touch the same row twice in one statement
+			_, err := tx.BulkUpsert(ctx, []*domain.Product{products[1], products[1]})
+			assert.Error(t, err)
+			_, err = tx.BulkUpsert(ctx, products[1:])
+			return err
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), countTestProducts(t, db))
+	})
+
+	t.Run("success - nested transaction rolled back", func(t *testing.T) {
+		db := openTestDB(t)
+		repo := NewCopyRepository(db)
+		products := testProducts(2)
+
+		aborted := errors.New("aborted")
+		err := repo.Transaction(ctx, func(tx domain.ProductRepository) error {
+			if _, err := tx.BulkUpsert(ctx, products[:1]); err != nil {
+				return err
+			}
+			err := tx.Transaction(ctx, func(nested domain.ProductRepository) error {
+				if _, err := nested.BulkUpsert(ctx, products[1:]); err != nil {
+					return err
+				}
+				return aborted
+			})
+			assert.ErrorIs(t, err, aborted)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), countTestProducts(t, db))
+	})
+
+	t.Run("error - rolled back", func(t *testing.T) {
+		db := openTestDB(t)
+		repo := NewCopyRepository(db)
+
+		aborted := errors.New("aborted")
+		err := repo.Transaction(ctx, func(tx domain.ProductRepository) error {
+			if _, err := tx.BulkUpsert(ctx, testProducts(2)); err != nil {
+				return err
+			}
+			return aborted
+		})
+
+		assert.ErrorIs(t, err, aborted)
+		assert.Equal(t, int64(0), countTestProducts(t, db))
+	})
+}
+
+// The integration tests and benchmarks need a migrated database, e.g.
+// TEST_DATABASE_URL=postgres://... go test -bench BulkUpsert ./internal/repository
+// Rows they write are deleted afterwards.
+func openTestDB(tb testing.TB) *gorm.DB {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		tb.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	require.NoError(tb, err)
+	tb.Cleanup(func() {
+		db.Exec("DELETE FROM products WHERE id >= ?", testIDBase)
+	})
+	return db
+}
+
+// testIDBase keeps test and benchmark rows apart from real products.
+const testIDBase = 900_000_000
+
+func testProducts(n int) []*domain.Product {
+	products := make([]*domain.Product, n)
+	for i := range products {
+		products[i] = &domain.Product{
+			ID:           testIDBase + i,
+			Name:         fmt.Sprintf("Product %d", i),
+			Description:  "Benchmark product",
+			Brand:        "Brand",
+			Category:     "Category",
+			Price:        99.99,
+			Currency:     "USD",
+			Stock:        10,
+			Ean:          "4006381333931",
+			Color:        "Red",
+			Size:         "M",
+			Availability: "in_stock",
+			InternalId:   i,
+			CreatedBy:    "benchmark",
+		}
+	}
+	return products
+}
+
+func benchmarkBulkUpsert(b *testing.B, newRepo func(*gorm.DB) domain.ProductRepository) {
+	repo := newRepo(openTestDB(b))
+
+	for _, n := range []int{1_000, 10_000} {
+		b.Run(fmt.Sprintf("rows=%d", n), func(b *testing.B) {
+			products := testProducts(n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.BulkUpsert(context.Background(), products); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(n*b.N)/b.Elapsed().Seconds(), "rows/s")
+		})
+	}
+}
+
+func BenchmarkBulkUpsert_Gorm(b *testing.B) {
+	benchmarkBulkUpsert(b, NewGormRepository)
+}
+
+func BenchmarkBulkUpsert_Copy(b *testing.B) {
+	benchmarkBulkUpsert(b, NewCopyRepository)
+}
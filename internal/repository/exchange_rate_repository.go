@@ -0,0 +1,45 @@
+// ============================================
+// internal/repository/exchange_rate_repository.go
+// ============================================
+package repository
+
+import (
+	"data-processing/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type exchangeRateRepository struct {
+	db *gorm.DB
+}
+
+func NewExchangeRateRepository(db *gorm.DB) domain.ExchangeRateRepository {
+	return &exchangeRateRepository{db: db}
+}
+
+func (r *exchangeRateRepository) Save(rate *domain.ExchangeRate) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "currency"}, {Name: "base_currency"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at"}),
+	}).Create(rate).Error
+}
+
+func (r *exchangeRateRepository) FindByBase(base string) (map[string]float64, error) {
+	var rates []*domain.ExchangeRate
+	if err := r.db.Where("base_currency = ?", base).Find(&rates).Error; err != nil {
+		return nil, err
+	}
+
+	byCurrency := make(map[string]float64, len(rates))
+	for _, rate := range rates {
+		byCurrency[rate.Currency] = rate.Rate
+	}
+	return byCurrency, nil
+}
+
+func (r *exchangeRateRepository) GetAll() ([]*domain.ExchangeRate, error) {
+	var rates []*domain.ExchangeRate
+	err := r.db.Order("base_currency, currency").Find(&rates).Error
+	return rates, err
+}
@@ -0,0 +1,105 @@
+// ============================================
+// internal/repository/exchange_rate_repository_test.go
+// ============================================
+package repository
+
+import (
+	"data-processing/internal/domain"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExchangeRateRepository_Save(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		repo := NewExchangeRateRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "exchange_rates" ("currency","base_currency","rate","updated_at") VALUES ($1,$2,$3,$4) ON CONFLICT ("currency","base_currency") DO UPDATE SET "rate"="excluded"."rate","updated_at"="excluded"."updated_at"`)).
+			WithArgs("USD", "EUR", 0.92, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.Save(&domain.ExchangeRate{
+			Currency:     "USD",
+			BaseCurrency: "EUR",
+			Rate:         0.92,
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		repo := NewExchangeRateRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "exchange_rates"`)).
+			WillReturnError(errors.New("database error"))
+		mock.ExpectRollback()
+
+		err := repo.Save(&domain.ExchangeRate{Currency: "USD", BaseCurrency: "EUR", Rate: 0.92})
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestExchangeRateRepository_FindByBase(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		repo := NewExchangeRateRepository(db)
+
+		rows := sqlmock.NewRows([]string{"currency", "base_currency", "rate"}).
+			AddRow("USD", "EUR", 0.92).
+			AddRow("GBP", "EUR", 1.17)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exchange_rates" WHERE base_currency = $1`)).
+			WithArgs("EUR").
+			WillReturnRows(rows)
+
+		rates, err := repo.FindByBase("EUR")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]float64{"USD": 0.92, "GBP": 1.17}, rates)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		repo := NewExchangeRateRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exchange_rates"`)).
+			WillReturnError(errors.New("database error"))
+
+		rates, err := repo.FindByBase("EUR")
+
+		assert.Error(t, err)
+		assert.Nil(t, rates)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestExchangeRateRepository_GetAll(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewExchangeRateRepository(db)
+
+	rows := sqlmock.NewRows([]string{"currency", "base_currency", "rate"}).
+		AddRow("GBP", "EUR", 1.17).
+		AddRow("USD", "EUR", 0.92)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "exchange_rates" ORDER BY base_currency, currency`)).
+		WillReturnRows(rows)
+
+	rates, err := repo.GetAll()
+
+	assert.NoError(t, err)
+	assert.Len(t, rates, 2)
+	assert.Equal(t, "USD", rates[1].Currency)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
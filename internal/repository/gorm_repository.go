@@ -4,11 +4,13 @@
 package repository
 
 import (
+	"context"
 	"data-processing/internal/domain"
 	"errors"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
 type gormRepository struct {
@@ -19,17 +21,17 @@ func NewGormRepository(db *gorm.DB) domain.ProductRepository {
 	return &gormRepository{db: db}
 }
 
-func (r *gormRepository) Create(product *domain.Product) error {
-	return r.db.Create(product).Error
+func (r *gormRepository) Create(ctx context.Context, product *domain.Product) error {
+	return r.db.WithContext(ctx).Create(product).Error
 }
 
-func (r *gormRepository) Update(product *domain.Product) error {
-	return r.db.Save(product).Error
+func (r *gormRepository) Update(ctx context.Context, product *domain.Product) error {
+	return r.db.WithContext(ctx).Save(product).Error
 }
 
-func (r *gormRepository) FindById(id int) (*domain.Product, error) {
+func (r *gormRepository) FindById(ctx context.Context, id int) (*domain.Product, error) {
 	var product domain.Product
-	err := r.db.Where("id = ?", id).First(&product).Error
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&product).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -39,20 +41,113 @@ func (r *gormRepository) FindById(id int) (*domain.Product, error) {
 	return &product, nil
 }
 
-func (r *gormRepository) BulkUpsert(products []*domain.Product) error {
+// BulkUpsert inserts products or updates the existing ones by ID. Postgres
+// reports per row whether it was inserted, as xmax is only zero for rows
+// the statement created.
+func (r *gormRepository) BulkUpsert(ctx context.Context, products []*domain.Product) ([]domain.UpsertOutcome, error) {
 	if len(products) == 0 {
-		return nil
+		return nil, nil
 	}
 
-	return r.db.Clauses(clause.OnConflict{
-		Columns: []clause.Column{{Name: "id"}},
-		DoUpdates: clause.AssignmentColumns([]string{
-			"name", "brand", "category", "price", "currency", "stock", "ean", "color", "size", "availability", "internal_id", "updated_at"}),
-	}).CreateInBatches(&products, 100).Error
+	stampProducts(products)
+
+	outcomes := make([]domain.UpsertOutcome, 0, len(products))
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for start := 0; start < len(products); start += upsertBatchSize {
+			end := min(start+upsertBatchSize, len(products))
+			query, args := upsertQuery(products[start:end])
+
+			var batch []domain.UpsertOutcome
+			if err := tx.Raw(query, args...).Scan(&batch).Error; err != nil {
+				return err
+			}
+			outcomes = append(outcomes, batch...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return outcomes, nil
 }
 
-func (r *gormRepository) GetAll() ([]*domain.Product, error) {
+func (r *gormRepository) GetAll(ctx context.Context) ([]*domain.Product, error) {
 	var products []*domain.Product
-	err := r.db.Find(&products).Error
+	err := r.db.WithContext(ctx).Find(&products).Error
 	return products, err
 }
+
+// Transaction runs fn in a database transaction. Inside it BulkUpsert's
+// own transaction becomes a savepoint, so a failed batch leaves the
+// transaction usable.
+func (r *gormRepository) Transaction(ctx context.Context, fn func(repo domain.ProductRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&gormRepository{db: tx})
+	})
+}
+
+// upsertBatchSize keeps each statement well below the Postgres limit of
+// 65535 bind parameters.
+const upsertBatchSize = 100
+
+// productColumns are written by BulkUpsert, upsertColumns are overwritten
+// when the product already exists.
+var (
+	productColumns = []string{
+		"id", "name", "description", "brand", "category", "price", "currency", "stock",
+		"ean", "color", "size", "availability", "internal_id", "original_price", "original_currency",
+		"created_by", "created_at", "updated_at"}
+	upsertColumns = []string{
+		"name", "brand", "category", "price", "currency", "stock", "ean", "color", "size", "availability", "internal_id",
+		"original_price", "original_currency", "updated_at"}
+)
+
+func upsertQuery(products []*domain.Product) (string, []interface{}) {
+	var query strings.Builder
+	query.WriteString("INSERT INTO products (")
+	query.WriteString(strings.Join(productColumns, ", "))
+	query.WriteString(") VALUES ")
+
+	placeholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(productColumns)), ", ") + ")"
+	args := make([]interface{}, 0, len(products)*len(productColumns))
+	for i, p := range products {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString(placeholders)
+		args = append(args, productValues(p)...)
+	}
+
+	query.WriteString(onConflictClause())
+
+	return query.String(), args
+}
+
+// onConflictClause updates existing products and returns the outcome of
+// every row written.
+func onConflictClause() string {
+	assignments := make([]string, 0, len(upsertColumns))
+	for _, column := range upsertColumns {
+		assignments = append(assignments, column+" = EXCLUDED."+column)
+	}
+	return " ON CONFLICT (id) DO UPDATE SET " + strings.Join(assignments, ", ") +
+		" RETURNING id, (xmax = 0) AS inserted"
+}
+
+// stampProducts sets the timestamps the way GORM would on create.
+func stampProducts(products []*domain.Product) {
+	now := time.Now()
+	for _, product := range products {
+		if product.CreatedAt.IsZero() {
+			product.CreatedAt = now
+		}
+		product.UpdatedAt = now
+	}
+}
+
+// productValues returns the values of product in productColumns order.
+func productValues(p *domain.Product) []interface{} {
+	return []interface{}{p.ID, p.Name, p.Description, p.Brand, p.Category, p.Price, p.Currency, p.Stock,
+		p.Ean, p.Color, p.Size, p.Availability, p.InternalId, p.OriginalPrice, p.OriginalCurrency,
+		p.CreatedBy, p.CreatedAt, p.UpdatedAt}
+}
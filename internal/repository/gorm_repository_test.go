@@ -4,6 +4,7 @@
 package repository
 
 import (
+	"context"
 	"data-processing/internal/domain"
 	"errors"
 	"regexp"
@@ -72,6 +73,8 @@ func TestGormRepository_Create(t *testing.T) {
 				sqlmock.AnyArg(), // Size
 				sqlmock.AnyArg(), // Availability
 				sqlmock.AnyArg(), // InternalId
+				sqlmock.AnyArg(), // OriginalPrice
+				sqlmock.AnyArg(), // OriginalCurrency
 				sqlmock.AnyArg(), // CreatedAt
 				sqlmock.AnyArg(), // UpdatedAt
 				sqlmock.AnyArg(), // CreatedBy
@@ -80,7 +83,7 @@ func TestGormRepository_Create(t *testing.T) {
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 		mock.ExpectCommit()
 
-		err := repo.Create(product)
+		err := repo.Create(context.Background(), product)
 
 		assert.NoError(t, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
@@ -104,7 +107,7 @@ func TestGormRepository_Create(t *testing.T) {
 			WillReturnError(errors.New("database error"))
 		mock.ExpectRollback()
 
-		err := repo.Create(product)
+		err := repo.Create(context.Background(), product)
 
 		assert.Error(t, err)
 		assert.Equal(t, "database error", err.Error())
@@ -134,7 +137,7 @@ func TestGormRepository_Update(t *testing.T) {
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
-		err := repo.Update(product)
+		err := repo.Update(context.Background(), product)
 
 		assert.NoError(t, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
@@ -158,7 +161,7 @@ func TestGormRepository_Update(t *testing.T) {
 			WillReturnError(errors.New("update failed"))
 		mock.ExpectRollback()
 
-		err := repo.Update(product)
+		err := repo.Update(context.Background(), product)
 
 		assert.Error(t, err)
 		assert.Equal(t, "update failed", err.Error())
@@ -209,7 +212,7 @@ func TestGormRepository_FindById(t *testing.T) {
 			WithArgs(1, 1).
 			WillReturnRows(rows)
 
-		product, err := repo.FindById(1)
+		product, err := repo.FindById(context.Background(), 1)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, product)
@@ -227,7 +230,7 @@ func TestGormRepository_FindById(t *testing.T) {
 			WithArgs(999, 1).
 			WillReturnError(gorm.ErrRecordNotFound)
 
-		product, err := repo.FindById(999)
+		product, err := repo.FindById(context.Background(), 999)
 
 		assert.NoError(t, err)
 		assert.Nil(t, product)
@@ -242,13 +245,30 @@ func TestGormRepository_FindById(t *testing.T) {
 			WithArgs(1, 1).
 			WillReturnError(errors.New("database connection error"))
 
-		product, err := repo.FindById(1)
+		product, err := repo.FindById(context.Background(), 1)
 
 		assert.Error(t, err)
 		assert.Nil(t, product)
 		assert.Equal(t, "database connection error", err.Error())
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
+
+	t.Run("error - cancelled context", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		repo := NewGormRepository(db)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id = $1`)).
+			WithArgs(1, 1).
+			WillDelayFor(time.Second)
+
+		product, err := repo.FindById(ctx, 1)
+
+		assert.Error(t, err)
+		assert.Nil(t, product)
+	})
 }
 
 func TestGormRepository_BulkUpsert(t *testing.T) {
@@ -280,13 +300,51 @@ func TestGormRepository_BulkUpsert(t *testing.T) {
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "products"`)).
-			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO products (id, name, description, brand, category, price, currency, stock, ean, color, size, availability, internal_id, original_price, original_currency, created_by, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18), ($19,`) + `.*` +
+			regexp.QuoteMeta(`ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name,`) + `.*` +
+			regexp.QuoteMeta(`updated_at = EXCLUDED.updated_at RETURNING id, (xmax = 0) AS inserted`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "inserted"}).
+				AddRow(1, true).
+				AddRow(2, false))
 		mock.ExpectCommit()
 
-		err := repo.BulkUpsert(products)
+		outcomes, err := repo.BulkUpsert(context.Background(), products)
 
 		assert.NoError(t, err)
+		assert.Equal(t, []domain.UpsertOutcome{
+			{ID: 1, Inserted: true},
+			{ID: 2, Inserted: false},
+		}, outcomes)
+		assert.False(t, products[0].CreatedAt.IsZero())
+		assert.False(t, products[0].UpdatedAt.IsZero())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("success - split into statements of 100 rows", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		repo := NewGormRepository(db)
+
+		products := make([]*domain.Product, 150)
+		first := sqlmock.NewRows([]string{"id", "inserted"})
+		second := sqlmock.NewRows([]string{"id", "inserted"})
+		for i := range products {
+			products[i] = &domain.Product{ID: i + 1, Name: "Product", Brand: "Brand", Category: "Category", CreatedBy: "test_user"}
+			if i < 100 {
+				first.AddRow(i+1, true)
+			} else {
+				second.AddRow(i+1, true)
+			}
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO products`)).WillReturnRows(first)
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO products`)).WillReturnRows(second)
+		mock.ExpectCommit()
+
+		outcomes, err := repo.BulkUpsert(context.Background(), products)
+
+		assert.NoError(t, err)
+		assert.Len(t, outcomes, 150)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
@@ -296,9 +354,10 @@ func TestGormRepository_BulkUpsert(t *testing.T) {
 
 		products := []*domain.Product{}
 
-		err := repo.BulkUpsert(products)
+		outcomes, err := repo.BulkUpsert(context.Background(), products)
 
 		assert.NoError(t, err)
+		assert.Empty(t, outcomes)
 	})
 
 	t.Run("error", func(t *testing.T) {
@@ -317,18 +376,75 @@ func TestGormRepository_BulkUpsert(t *testing.T) {
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "products"`)).
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO products`)).
 			WillReturnError(errors.New("bulk insert failed"))
 		mock.ExpectRollback()
 
-		err := repo.BulkUpsert(products)
+		outcomes, err := repo.BulkUpsert(context.Background(), products)
 
 		assert.Error(t, err)
+		assert.Nil(t, outcomes)
 		assert.Equal(t, "bulk insert failed", err.Error())
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
 
+func TestGormRepository_Transaction(t *testing.T) {
+	products := func() []*domain.Product {
+		return []*domain.Product{{ID: 1, Name: "Product 1", Brand: "Brand 1", Category: "Category 1", CreatedBy: "test_user"}}
+	}
+
+	t.Run("success - batches committed together", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		repo := NewGormRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`SAVEPOINT`)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO products`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "inserted"}).AddRow(1, true))
+		mock.ExpectExec(regexp.QuoteMeta(`SAVEPOINT`)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO products`)).
+			WillReturnError(errors.New("bulk insert failed"))
+		mock.ExpectExec(regexp.QuoteMeta(`ROLLBACK TO SAVEPOINT`)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := repo.Transaction(context.Background(), func(tx domain.ProductRepository) error {
+			if _, err := tx.BulkUpsert(context.Background(), products()); err != nil {
+				return err
+			}
+			// A failed batch only undoes itself
+			_, err := tx.BulkUpsert(context.Background(), products())
+			assert.Error(t, err)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("error - rolled back", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		repo := NewGormRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`SAVEPOINT`)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO products`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "inserted"}).AddRow(1, true))
+		mock.ExpectRollback()
+
+		aborted := errors.New("aborted")
+		err := repo.Transaction(context.Background(), func(tx domain.ProductRepository) error {
+			if _, err := tx.BulkUpsert(context.Background(), products()); err != nil {
+				return err
+			}
+			return aborted
+		})
+
+		assert.ErrorIs(t, err, aborted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestGormRepository_GetAll(t *testing.T) {
 	t.Run("success - with products", func(t *testing.T) {
 		db, mock := setupTestDB(t)
@@ -349,7 +465,7 @@ func TestGormRepository_GetAll(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products"`)).
 			WillReturnRows(rows)
 
-		products, err := repo.GetAll()
+		products, err := repo.GetAll(context.Background())
 
 		assert.NoError(t, err)
 		assert.NotNil(t, products)
@@ -372,7 +488,7 @@ func TestGormRepository_GetAll(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products"`)).
 			WillReturnRows(rows)
 
-		products, err := repo.GetAll()
+		products, err := repo.GetAll(context.Background())
 
 		assert.NoError(t, err)
 		assert.NotNil(t, products)
@@ -387,7 +503,7 @@ func TestGormRepository_GetAll(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products"`)).
 			WillReturnError(errors.New("query failed"))
 
-		products, err := repo.GetAll()
+		products, err := repo.GetAll(context.Background())
 
 		assert.Error(t, err)
 		assert.Nil(t, products)
@@ -0,0 +1,50 @@
+// ============================================
+// internal/repository/import_job_repository.go
+// ============================================
+package repository
+
+import (
+	"data-processing/internal/domain"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type importJobRepository struct {
+	db *gorm.DB
+}
+
+func NewImportJobRepository(db *gorm.DB) domain.ImportJobRepository {
+	return &importJobRepository{db: db}
+}
+
+func (r *importJobRepository) Create(job *domain.ImportJob) error {
+	return r.db.Create(job).Error
+}
+
+func (r *importJobRepository) Update(job *domain.ImportJob) error {
+	return r.db.Save(job).Error
+}
+
+func (r *importJobRepository) FindByID(id string) (*domain.ImportJob, error) {
+	var job domain.ImportJob
+	err := r.db.Where("id = ?", id).First(&job).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *importJobRepository) List(status domain.JobStatus, limit int) ([]*domain.ImportJob, error) {
+	query := r.db.Order("created_at DESC").Limit(limit)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var jobs []*domain.ImportJob
+	err := query.Find(&jobs).Error
+	return jobs, err
+}
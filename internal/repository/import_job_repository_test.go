@@ -0,0 +1,111 @@
+// ============================================
+// internal/repository/import_job_repository_test.go
+// ============================================
+package repository
+
+import (
+	"data-processing/internal/domain"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestImportJobRepository_Create(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewImportJobRepository(db)
+
+	job := &domain.ImportJob{
+		ID:        "9b2f6c1e-5d8a-4c1b-9a57-3f0f5e7c2d11",
+		Status:    domain.JobQueued,
+		FilePaths: domain.StringList{"/csv/product-csv-1.csv"},
+		CreatedAt: time.Now(),
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "import_jobs"`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := repo.Create(job)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImportJobRepository_FindByID(t *testing.T) {
+	t.Run("success - found", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		repo := NewImportJobRepository(db)
+
+		rows := sqlmock.NewRows([]string{
+			"id", "status", "file_paths", "inserted", "file_results", "processing_time",
+		}).AddRow(
+			"job-1", "succeeded", []byte(`["/csv/a.csv"]`), 2,
+			[]byte(`{"/csv/a.csv":{"TotalRecords":2,"Inserted":2}}`), int64(time.Second),
+		)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "import_jobs" WHERE id = $1`)).
+			WithArgs("job-1", 1).
+			WillReturnRows(rows)
+
+		job, err := repo.FindByID("job-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.JobSucceeded, job.Status)
+		assert.Equal(t, domain.StringList{"/csv/a.csv"}, job.FilePaths)
+		assert.Equal(t, 2, job.FileResults["/csv/a.csv"].Inserted)
+		assert.Equal(t, time.Second, job.ProcessingTime)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found - returns nil", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		repo := NewImportJobRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "import_jobs" WHERE id = $1`)).
+			WithArgs("missing", 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		job, err := repo.FindByID("missing")
+
+		assert.NoError(t, err)
+		assert.Nil(t, job)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestImportJobRepository_List(t *testing.T) {
+	t.Run("success - all statuses", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		repo := NewImportJobRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "import_jobs" ORDER BY created_at DESC LIMIT $1`)).
+			WithArgs(50).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("job-2").AddRow("job-1"))
+
+		jobs, err := repo.List("", 50)
+
+		assert.NoError(t, err)
+		assert.Len(t, jobs, 2)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("success - filtered by status", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		repo := NewImportJobRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "import_jobs" WHERE status = $1 ORDER BY created_at DESC LIMIT $2`)).
+			WithArgs(domain.JobRunning, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("job-3"))
+
+		jobs, err := repo.List(domain.JobRunning, 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, jobs, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,49 @@
+// ============================================
+// internal/repository/import_profile_repository.go
+// ============================================
+package repository
+
+import (
+	"data-processing/internal/domain"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type importProfileRepository struct {
+	db *gorm.DB
+}
+
+func NewImportProfileRepository(db *gorm.DB) domain.ImportProfileRepository {
+	return &importProfileRepository{db: db}
+}
+
+func (r *importProfileRepository) Save(profile *domain.ImportProfile) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"column_mapping", "delimiter", "quote", "comment", "lazy_quotes", "encoding",
+			"decimal_separator", "transforms", "rules", "ean_strictness", "ean_format",
+			"convert_currency", "availability_synonyms", "availability_stock", "sheet", "header_row",
+			"updated_at"}),
+	}).Create(profile).Error
+}
+
+func (r *importProfileRepository) FindByName(name string) (*domain.ImportProfile, error) {
+	var profile domain.ImportProfile
+	err := r.db.Where("name = ?", name).First(&profile).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (r *importProfileRepository) GetAll() ([]*domain.ImportProfile, error) {
+	var profiles []*domain.ImportProfile
+	err := r.db.Order("name").Find(&profiles).Error
+	return profiles, err
+}
@@ -0,0 +1,146 @@
+// ============================================
+// internal/repository/import_profile_repository_test.go
+// ============================================
+package repository
+
+import (
+	"data-processing/internal/domain"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestImportProfileRepository_Save(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		repo := NewImportProfileRepository(db)
+
+		profile := &domain.ImportProfile{
+			Name:                 "supplier-eu",
+			ColumnMapping:        domain.StringMap{"Artikel": "id"},
+			Delimiter:            ";",
+			Transforms:           domain.FieldTransforms{"currency": {"upper"}},
+			Rules:                domain.ValidationRules{"ean": {Required: true}},
+			EanStrictness:        "reject",
+			EanFormat:            "gtin14",
+			ConvertCurrency:      true,
+			AvailabilitySynonyms: domain.StringMap{"Lieferbar": "in_stock"},
+			AvailabilityStock:    "flag",
+			Sheet:                "Products",
+			HeaderRow:            2,
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "import_profiles"`)).
+			WithArgs(
+				"supplier-eu",
+				`{"Artikel":"id"}`,
+				";",
+				"",
+				"",
+				false,
+				"",
+				"",
+				`{"currency":["upper"]}`,
+				`{"ean":{"Required":true,"Min":null,"Max":null,"MaxLength":0,"Pattern":"","Allowed":null}}`,
+				"reject",
+				"gtin14",
+				true,
+				`{"Lieferbar":"in_stock"}`,
+				"flag",
+				"Products",
+				2,
+				sqlmock.AnyArg(), // CreatedAt
+				sqlmock.AnyArg(), // UpdatedAt
+			).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.Save(profile)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		repo := NewImportProfileRepository(db)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "import_profiles"`)).
+			WillReturnError(errors.New("database error"))
+		mock.ExpectRollback()
+
+		err := repo.Save(&domain.ImportProfile{Name: "supplier-eu"})
+
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestImportProfileRepository_FindByName(t *testing.T) {
+	t.Run("success - found", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		repo := NewImportProfileRepository(db)
+
+		rows := sqlmock.NewRows([]string{
+			"name", "column_mapping", "delimiter", "encoding",
+			"decimal_separator", "transforms", "created_at", "updated_at",
+		}).AddRow(
+			"supplier-eu", []byte(`{"Artikel":"id"}`), ";", "windows-1252",
+			",", []byte(`{"currency":["upper"]}`), time.Now(), time.Now(),
+		)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "import_profiles" WHERE name = $1`)).
+			WithArgs("supplier-eu", 1).
+			WillReturnRows(rows)
+
+		profile, err := repo.FindByName("supplier-eu")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, profile)
+		assert.Equal(t, domain.StringMap{"Artikel": "id"}, profile.ColumnMapping)
+		assert.Equal(t, domain.FieldTransforms{"currency": {"upper"}}, profile.Transforms)
+		assert.Equal(t, "windows-1252", profile.Encoding)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found - returns nil", func(t *testing.T) {
+		db, mock := setupTestDB(t)
+		repo := NewImportProfileRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "import_profiles" WHERE name = $1`)).
+			WithArgs("missing", 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		profile, err := repo.FindByName("missing")
+
+		assert.NoError(t, err)
+		assert.Nil(t, profile)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestImportProfileRepository_GetAll(t *testing.T) {
+	db, mock := setupTestDB(t)
+	repo := NewImportProfileRepository(db)
+
+	rows := sqlmock.NewRows([]string{"name", "column_mapping", "transforms"}).
+		AddRow("a", []byte(`{}`), []byte(`{}`)).
+		AddRow("b", nil, nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "import_profiles" ORDER BY name`)).
+		WillReturnRows(rows)
+
+	profiles, err := repo.GetAll()
+
+	assert.NoError(t, err)
+	assert.Len(t, profiles, 2)
+	assert.Equal(t, "b", profiles[1].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
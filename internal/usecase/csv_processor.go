@@ -4,108 +4,450 @@
 package usecase
 
 import (
+	"context"
 	"data-processing/internal/domain"
+	"data-processing/pkg/availability"
 	"data-processing/pkg/csv"
+	"data-processing/pkg/currency"
+	"data-processing/pkg/gtin"
+	"data-processing/pkg/validator"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type csvProcessorUsecase struct {
-	repo        domain.ProductRepository
-	logger      domain.Logger
-	csvReader   *csv.Reader
-	workerCount int
-	batchSize   int
+	repo         domain.ProductRepository
+	profileRepo  domain.ImportProfileRepository
+	rateRepo     domain.ExchangeRateRepository
+	logger       domain.Logger
+	csvReader    *csv.Reader
+	paths        domain.PathResolver
+	workerCount  int
+	batchSize    int
+	baseCurrency string
 }
 
 func NewCSVProcessorUsecase(
 	repo domain.ProductRepository,
+	profileRepo domain.ImportProfileRepository,
+	rateRepo domain.ExchangeRateRepository,
+	csvReader *csv.Reader,
+	paths domain.PathResolver,
 	logger domain.Logger,
 	workerCount int,
 	batchSize int,
+	baseCurrency string,
 ) domain.CSVProcessorUsecase {
 	return &csvProcessorUsecase{
-		repo:        repo,
-		logger:      logger,
-		csvReader:   csv.NewReader(),
-		workerCount: workerCount,
-		batchSize:   batchSize,
+		repo:         repo,
+		profileRepo:  profileRepo,
+		rateRepo:     rateRepo,
+		logger:       logger,
+		csvReader:    csvReader,
+		paths:        paths,
+		workerCount:  workerCount,
+		batchSize:    batchSize,
+		baseCurrency: baseCurrency,
 	}
 }
 
+// ProcessCSVFiles imports the files in order. Once ctx is done, or the
+// control cancels the run, it stops at the next row and returns a result
+// marked Cancelled that counts the rows committed so far. An atomic request
+// that did not complete is rolled back and returns a result marked
+// RolledBack.
 func (u *csvProcessorUsecase) ProcessCSVFiles(
+	ctx context.Context,
 	filePaths []string,
+	options domain.ImportOptions,
 	progressChan chan<- *domain.ProgressUpdate,
 ) (*domain.FinalResult, error) {
 	start := time.Now()
+
+	settings, err := u.resolveOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
 	u.logger.Info("Starting CSV processing with %d workers", u.workerCount)
 
 	finalResult := &domain.FinalResult{
-		FileResults: make(map[string]*domain.FileResult),
+		FileResults: make(domain.FileResultMap),
+	}
+
+	if settings.atomic == domain.AtomicRequest {
+		if err := u.processFilesAtomically(ctx, filePaths, options, settings, progressChan, finalResult); err != nil {
+			return nil, err
+		}
+	} else {
+		u.processFiles(ctx, u.repo, filePaths, options, settings, progressChan, finalResult)
 	}
 
-	// Process each file
+	finalResult.ProcessingTime = time.Since(start)
+	u.logger.Info("Processing completed in %v", finalResult.ProcessingTime)
+	u.logger.Info("Total: %d | Inserted: %d | Updated: %d | Failed: %d | Committed: %d",
+		finalResult.TotalRecords, finalResult.Inserted, finalResult.Updated, finalResult.Failed, finalResult.Committed)
+
+	return finalResult, nil
+}
+
+// processFiles imports each file, and each file inside a zip archive,
+// writing with repo.
+func (u *csvProcessorUsecase) processFiles(
+	ctx context.Context,
+	repo domain.ProductRepository,
+	filePaths []string,
+	options domain.ImportOptions,
+	settings importSettings,
+	progressChan chan<- *domain.ProgressUpdate,
+	finalResult *domain.FinalResult,
+) {
+files:
 	for _, filePath := range filePaths {
-		u.logger.Info("Processing file: %s", filePath)
+		if err := checkpoint(ctx, options.Control); err != nil {
+			finalResult.Cancelled = true
+			finalResult.CancelReason = err.Error()
+			break
+		}
+		if requestFailed(settings, finalResult) {
+			break
+		}
 
-		fileResult, err := u.processFileWithWorkers(filePath, progressChan)
+		inputs, err := u.inputFiles(filePath)
 		if err != nil {
-			u.logger.Error("Failed to process file %s: %v", filePath, err)
-			finalResult.Errors = append(finalResult.Errors, fmt.Sprintf("File %s: %v", filePath, err))
+			u.recordFileError(finalResult, filePath, err)
 			continue
 		}
 
-		finalResult.FileResults[filePath] = fileResult
-		finalResult.TotalRecords += fileResult.TotalRecords
-		finalResult.Inserted += fileResult.Inserted
-		finalResult.Updated += fileResult.Updated
-		finalResult.Failed += fileResult.Failed
-		finalResult.Errors = append(finalResult.Errors, fileResult.Errors...)
+		for i, input := range inputs {
+			// Archive entries are checkpointed like separate files
+			if i > 0 {
+				if err := checkpoint(ctx, options.Control); err != nil {
+					finalResult.Cancelled = true
+					finalResult.CancelReason = err.Error()
+					break files
+				}
+				if requestFailed(settings, finalResult) {
+					break files
+				}
+			}
+
+			u.logger.Info("Processing file: %s", input.key)
+
+			fileResult, err := u.processFileWithWorkers(ctx, repo, input, settings, options.Control, progressChan)
+			if errors.Is(err, domain.ErrJobCancelled) {
+				u.logger.Info("Processing cancelled during file %s: %v", input.key, err)
+				finalResult.AddFileResult(input.key, fileResult)
+				finalResult.Cancelled = true
+				finalResult.CancelReason = err.Error()
+				break files
+			}
+			if err != nil {
+				u.recordFileError(finalResult, input.key, err)
+				continue
+			}
+
+			finalResult.AddFileResult(input.key, fileResult)
+		}
+	}
+}
+
+// processFilesAtomically imports every file in one transaction, which is
+// rolled back unless every file was read to the end. Once a file failed
+// the remaining files are skipped. A rolled back request
+// reports its rows, but none of them as written.
+func (u *csvProcessorUsecase) processFilesAtomically(
+	ctx context.Context,
+	filePaths []string,
+	options domain.ImportOptions,
+	settings importSettings,
+	progressChan chan<- *domain.ProgressUpdate,
+	finalResult *domain.FinalResult,
+) error {
+	var reason string
+	err := u.repo.Transaction(ctx, func(repo domain.ProductRepository) error {
+		u.processFiles(ctx, repo, filePaths, options, settings, progressChan, finalResult)
+		if reason = rollbackReason(finalResult); reason != "" {
+			return errRequestRolledBack
+		}
+		return nil
+	})
+
+	switch {
+	case errors.Is(err, errRequestRolledBack):
+	case err != nil && cancellation(ctx) != nil:
+		// Cancelled before the transaction began or while it committed
+		reason = "job cancelled"
+		finalResult.Cancelled = true
+		finalResult.CancelReason = cancellation(ctx).Error()
+	case err != nil:
+		return fmt.Errorf("atomic import failed: %w", err)
+	default:
+		return nil
 	}
 
-	finalResult.ProcessingTime = time.Since(start)
-	u.logger.Info("Processing completed in %v", finalResult.ProcessingTime)
-	u.logger.Info("Total: %d | Inserted: %d | Updated: %d | Failed: %d",
-		finalResult.TotalRecords, finalResult.Inserted, finalResult.Updated, finalResult.Failed)
+	finalResult.RolledBack = true
+	finalResult.RollbackReason = reason
+	finalResult.Inserted = 0
+	finalResult.Updated = 0
+	finalResult.Committed = 0
+	for _, fileResult := range finalResult.FileResults {
+		rollBack(fileResult)
+	}
+	errorMsg := "Rolled back every file: " + reason
+	finalResult.Errors = append(finalResult.Errors, errorMsg)
+	u.logger.Error(errorMsg)
+	return nil
+}
 
-	return finalResult, nil
+// requestFailed reports whether an atomic request is bound to be rolled
+// back, so its remaining files need not be read.
+func requestFailed(settings importSettings, finalResult *domain.FinalResult) bool {
+	return settings.atomic == domain.AtomicRequest && rollbackReason(finalResult) != ""
 }
 
+// errRequestRolledBack rolls back the transaction of an atomic request.
+var errRequestRolledBack = errors.New("request rolled back")
+
+// rollbackReason returns why an atomic request cannot be committed, or an
+// empty string.
+func rollbackReason(finalResult *domain.FinalResult) string {
+	if finalResult.Cancelled {
+		return "job cancelled"
+	}
+	// Sorted so the reason does not depend on map order
+	filePaths := make([]string, 0, len(finalResult.FileResults))
+	for filePath := range finalResult.FileResults {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+	for _, filePath := range filePaths {
+		fileResult := finalResult.FileResults[filePath]
+		switch {
+		case fileResult.Error != "":
+			return fmt.Sprintf("file %s failed: %s", filePath, fileResult.Error)
+		case fileResult.Aborted:
+			return fmt.Sprintf("file %s was aborted", filePath)
+		}
+	}
+	return ""
+}
+
+// recordFileError records a file that could not be processed at all.
+func (u *csvProcessorUsecase) recordFileError(finalResult *domain.FinalResult, filePath string, err error) {
+	u.logger.Error("Failed to process file %s: %v", filePath, err)
+	finalResult.Errors = append(finalResult.Errors, fmt.Sprintf("File %s: %v", filePath, err))
+	finalResult.FileResults[filePath] = &domain.FileResult{Error: err.Error()}
+}
+
+// resolveOptions loads the requested import profile, falling back to the
+// default CSV layout and rules when none is given, and applies the dialect
+// overrides. The exchange rates of a profile converting prices are loaded
+// once, so an import uses the same rates throughout.
+func (u *csvProcessorUsecase) resolveOptions(options domain.ImportOptions) (importSettings, error) {
+	settings, err := importOptions(u.profileRepo, options)
+	if err != nil || !settings.convertCurrency {
+		return settings, err
+	}
+
+	if u.baseCurrency == "" {
+		return importSettings{}, fmt.Errorf("%w: currency conversion requires BASE_CURRENCY to be set", domain.ErrInvalidProfile)
+	}
+	rates, err := u.rateRepo.FindByBase(u.baseCurrency)
+	if err != nil {
+		return importSettings{}, fmt.Errorf("failed to load exchange rates: %w", err)
+	}
+	settings.prices = &priceConversion{base: u.baseCurrency, rates: rates}
+	return settings, nil
+}
+
+// inputFile is a file to import, or a file inside a zip archive when entry
+// is set. key names it in FinalResult.FileResults.
+type inputFile struct {
+	key   string
+	path  string
+	entry string
+}
+
+// inputFiles resolves filePath inside the import directory and lists the
+// files it holds: the file itself, or every CSV and JSON file in a zip
+// archive keyed as "archive.zip!/inner.csv".
+func (u *csvProcessorUsecase) inputFiles(filePath string) ([]inputFile, error) {
+	path, err := u.paths.Resolve(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := csv.ArchiveEntries(path)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		return []inputFile{{key: filePath, path: path}}, nil
+	}
+
+	inputs := make([]inputFile, 0, len(entries))
+	for _, entry := range entries {
+		inputs = append(inputs, inputFile{
+			key:   filePath + csv.ArchiveSeparator + entry,
+			path:  path,
+			entry: entry,
+		})
+	}
+	return inputs, nil
+}
+
+// open opens the record source of input in its format.
+func (u *csvProcessorUsecase) open(input inputFile, csvOptions csv.Options) (domain.RecordSource, error) {
+	if input.entry != "" {
+		return u.csvReader.OpenEntry(input.path, input.entry, csvOptions)
+	}
+	return u.csvReader.Open(input.path, csvOptions)
+}
+
+// processFileWithWorkers imports one file with repo. An atomic file, or
+// one under an error policy that rolls back, is written in one transaction
+// that is only committed when the file was read and written to the end
+// and neither aborted nor cancelled. The files of an atomic request share the
+// request's transaction instead.
 func (u *csvProcessorUsecase) processFileWithWorkers(
-	filePath string,
+	ctx context.Context,
+	repo domain.ProductRepository,
+	input inputFile,
+	settings importSettings,
+	control domain.JobControl,
 	progressChan chan<- *domain.ProgressUpdate,
 ) (*domain.FileResult, error) {
-	// Read CSV file
-	records, err := u.csvReader.ReadCSV(filePath)
+	transactional := settings.atomic == domain.AtomicFile || settings.errorPolicy.Rollback
+	if !transactional || settings.atomic == domain.AtomicRequest {
+		return u.processFile(ctx, repo, input, settings, control, progressChan)
+	}
+
+	var fileResult *domain.FileResult
+	var fileErr error
+	err := repo.Transaction(ctx, func(repo domain.ProductRepository) error {
+		fileResult, fileErr = u.processFile(ctx, repo, input, settings, control, progressChan)
+		if fileErr != nil {
+			return fileErr
+		}
+		if fileResult.Aborted || fileResult.Error != "" {
+			return errFileIncomplete
+		}
+		return nil
+	})
+
+	switch {
+	case fileErr != nil:
+		if fileResult != nil {
+			rollBack(fileResult)
+		}
+		return fileResult, fileErr
+	case errors.Is(err, errFileIncomplete):
+		rollBack(fileResult)
+		u.logger.Info("File %s: writes rolled back", input.key)
+		return fileResult, nil
+	case err != nil:
+		return nil, fmt.Errorf("commit failed: %w", err)
+	}
+	return fileResult, nil
+}
+
+// errFileIncomplete rolls back the transaction of a file that was aborted
+// or not read and written to the end.
+var errFileIncomplete = errors.New("file incomplete")
+
+// rollBack counts the writes of a rolled back file as undone.
+func rollBack(fileResult *domain.FileResult) {
+	fileResult.RolledBack = true
+	fileResult.Inserted = 0
+	fileResult.Updated = 0
+	fileResult.Committed = 0
+}
+
+// processFile streams input through the workers and writes the valid rows
+// with repo in batches. Once the error policy aborts the file, or a write
+// of an atomic import fails, reading stops and the rows not yet written
+// are skipped.
+func (u *csvProcessorUsecase) processFile(
+	ctx context.Context,
+	repo domain.ProductRepository,
+	input inputFile,
+	settings importSettings,
+	control domain.JobControl,
+	progressChan chan<- *domain.ProgressUpdate,
+) (*domain.FileResult, error) {
+	filePath := input.key
+	stream, err := u.open(input, settings.csv)
 	if err != nil {
 		return nil, err
 	}
+	defer stream.Close()
+
+	totalBytes := stream.Size()
+	u.logger.Info("File %s: Streaming %d bytes", filePath, totalBytes)
 
-	totalRecords := len(records)
-	u.logger.Info("File %s: Found %d records", filePath, totalRecords)
+	// Aborting the file stops the reader and workers like a cancellation,
+	// without cancelling the run
+	fileCtx, abort := context.WithCancel(ctx)
+	defer abort()
 
-	// Create channels
-	jobChan := make(chan *domain.ProcessJob, totalRecords)
-	resultChan := make(chan *domain.ProcessResult, totalRecords)
+	// Create bounded channels so memory stays flat regardless of file size
+	jobChan := make(chan *domain.ProcessJob, u.workerCount*2)
+	resultChan := make(chan *domain.ProcessResult, u.workerCount*2)
 
 	// Start workers
 	var wg sync.WaitGroup
 	for i := 0; i < u.workerCount; i++ {
 		wg.Add(1)
-		go u.worker(i+1, jobChan, resultChan, &wg)
+		go u.worker(fileCtx, i+1, settings, jobChan, resultChan, &wg)
 	}
 
-	// Send jobs to workers
+	// Stream records to workers
+	var readCount, readOffset atomic.Int64
+	var readErr, controlErr error
 	go func() {
-		for _, record := range records {
-			jobChan <- &domain.ProcessJob{
-				Record:   record,
-				FilePath: filePath,
+		defer close(jobChan)
+		for {
+			// Honour pause, cancel and deadlines between rows
+			if err := checkpoint(fileCtx, control); err != nil {
+				controlErr = err
+				return
+			}
+
+			job := &domain.ProcessJob{FilePath: filePath}
+			record, err := stream.Next()
+			var rowErr *domain.RowError
+			switch {
+			case errors.As(err, &rowErr):
+				// Counted as a failed row, the rest of the file is read
+				job.Record = &domain.CSVRecord{RowNumber: rowErr.Row}
+				job.Error = rowErr.Err
+			case err == io.EOF:
+				return
+			case err != nil:
+				readErr = err
+				return
+			default:
+				job.Record = record
+			}
+			readCount.Add(1)
+			readOffset.Store(stream.Offset())
+
+			select {
+			case jobChan <- job:
+			case <-fileCtx.Done():
+				controlErr = cancellation(fileCtx)
+				return
 			}
 		}
-		close(jobChan)
 	}()
 
 	// Close workers
@@ -115,71 +457,255 @@ func (u *csvProcessorUsecase) processFileWithWorkers(
 	}()
 
 	// Collect results and send progress updates
-	fileResult := &domain.FileResult{
-		TotalRecords: totalRecords,
-	}
+	fileResult := &domain.FileResult{}
 
 	processedCount := 0
-	batch := make([]*domain.Product, 0, u.batchSize)
+	batch := make([]*domain.ProcessResult, 0, u.batchSize)
+	batchIDs := make(map[int]struct{}, u.batchSize)
+
+	// flush writes the batch and applies the error policy to the rows that
+	// failed to be written. An atomic import cannot commit without them,
+	// so it stops at the first failed write
+	var writeErr error
+	flush := func() {
+		err := u.upsertBatch(ctx, repo, batch, fileResult)
+		batch = batch[:0]
+		clear(batchIDs)
+		if err != nil && settings.atomic != domain.AtomicOff {
+			writeErr = err
+			abort()
+			return
+		}
+		u.applyErrorPolicy(fileResult, settings.errorPolicy, processedCount, false, abort)
+	}
+	stopped := func() bool {
+		return fileResult.Aborted || writeErr != nil
+	}
 
 	for result := range resultChan {
+		// Drain what the workers still send once stopped
+		if stopped() {
+			continue
+		}
 		processedCount++
 
+		if result.Warning != "" {
+			u.recordWarning(fileResult, result)
+		}
+
 		if result.Error != nil {
-			fileResult.Failed++
-			errorMsg := fmt.Sprintf("Row %d (SKU: %s): %v",
-				result.RowNumber, result.Product.Name, result.Error)
-			fileResult.Errors = append(fileResult.Errors, errorMsg)
-			u.logger.Error(errorMsg)
+			u.recordFailure(fileResult, result, result.Error)
+			u.applyErrorPolicy(fileResult, settings.errorPolicy, processedCount, false, abort)
 		} else {
-			if result.IsUpdate {
-				fileResult.Updated++
-			} else {
-				fileResult.Inserted++
+			// A statement can only upsert an ID once, so a repeated ID
+			// flushes the batch first
+			if _, ok := batchIDs[result.Product.ID]; ok {
+				flush()
+				if stopped() {
+					continue
+				}
 			}
-			batch = append(batch, result.Product)
+			batch = append(batch, result)
+			batchIDs[result.Product.ID] = struct{}{}
 
 			// Batch upsert
 			if len(batch) >= u.batchSize {
-				if err := u.repo.BulkUpsert(batch); err != nil {
-					u.logger.Error("Batch upsert failed: %v", err)
-				}
-				batch = batch[:0]
+				flush()
 			}
 		}
 
-		// Send progress update every 10 records or at completion
-		if processedCount%10 == 0 || processedCount == totalRecords {
-			percentage := float64(processedCount) / float64(totalRecords) * 100
-			u.logger.Progress(filePath, processedCount, totalRecords, percentage)
-
-			if progressChan != nil {
-				progressChan <- &domain.ProgressUpdate{
-					FileName:       filePath,
-					TotalRecords:   totalRecords,
-					ProcessedCount: processedCount,
-					Percentage:     percentage,
-					Inserted:       fileResult.Inserted,
-					Updated:        fileResult.Updated,
-					Failed:         fileResult.Failed,
-					Message:        fmt.Sprintf("Processing %s: %.2f%% complete", filePath, percentage),
-				}
-			}
+		// Send progress update every 10 records
+		if processedCount%10 == 0 {
+			u.sendProgress(progressChan, filePath, fileResult, processedCount,
+				int(readCount.Load()), readOffset.Load(), totalBytes)
 		}
 	}
 
-	// Final batch upsert
-	if len(batch) > 0 {
-		if err := u.repo.BulkUpsert(batch); err != nil {
-			u.logger.Error("Final batch upsert failed: %v", err)
-		}
+	// Final batch upsert, skipped when the run was cancelled or the file
+	// stopped
+	if len(batch) > 0 && ctx.Err() == nil && !stopped() {
+		flush()
+	}
+
+	// The failure rate of a short file is judged once it is complete
+	if ctx.Err() == nil && readErr == nil && writeErr == nil {
+		u.applyErrorPolicy(fileResult, settings.errorPolicy, processedCount, true, abort)
+	}
+
+	if writeErr != nil {
+		errorMsg := fmt.Sprintf("Write failed after %d records: %v", processedCount, writeErr)
+		fileResult.Error = errorMsg
+		fileResult.Errors = append(fileResult.Errors, errorMsg)
+		u.logger.Error("File %s: %s", filePath, errorMsg)
+	}
+
+	// A file that was not read to the end failed, even though the rows
+	// read before the error were written
+	if readErr != nil && !stopped() {
+		errorMsg := fmt.Sprintf("Read aborted after %d records: %v", processedCount, readErr)
+		fileResult.Error = errorMsg
+		fileResult.Errors = append(fileResult.Errors, errorMsg)
+		u.logger.Error("File %s: %s", filePath, errorMsg)
+	}
+
+	fileResult.TotalRecords = processedCount
+
+	// Stopping cancels the file context, not the run. The run's context
+	// may end after the last row was read
+	if stopped() || controlErr == nil {
+		controlErr = cancellation(ctx)
+	}
+	if controlErr != nil {
+		u.sendProgress(progressChan, filePath, fileResult, processedCount,
+			processedCount, readOffset.Load(), totalBytes)
+		return fileResult, controlErr
+	}
+
+	if stopped() || readErr != nil {
+		u.sendProgress(progressChan, filePath, fileResult, processedCount,
+			processedCount, readOffset.Load(), totalBytes)
+		return fileResult, nil
 	}
 
+	u.sendProgress(progressChan, filePath, fileResult, processedCount,
+		processedCount, totalBytes, totalBytes)
+
 	return fileResult, nil
 }
 
+// upsertBatch writes the batch and counts each row as inserted or updated
+// from what the database reports. Every row fails when the upsert fails.
+// It returns why rows of the batch were not written, unless the run was
+// cancelled.
+func (u *csvProcessorUsecase) upsertBatch(
+	ctx context.Context,
+	repo domain.ProductRepository,
+	batch []*domain.ProcessResult,
+	fileResult *domain.FileResult,
+) error {
+	products := make([]*domain.Product, 0, len(batch))
+	for _, result := range batch {
+		products = append(products, result.Product)
+	}
+
+	outcomes, err := repo.BulkUpsert(ctx, products)
+	if err != nil {
+		// Rows interrupted by cancellation are neither failed nor committed
+		if ctx.Err() != nil {
+			return nil
+		}
+		u.logger.Error("Batch upsert failed: %v", err)
+		for _, result := range batch {
+			u.recordFailure(fileResult, result, err)
+		}
+		return err
+	}
+
+	inserted := make(map[int]bool, len(outcomes))
+	for _, outcome := range outcomes {
+		inserted[outcome.ID] = outcome.Inserted
+	}
+
+	var writeErr error
+	for _, result := range batch {
+		isInserted, ok := inserted[result.Product.ID]
+		if !ok {
+			writeErr = errNotWritten
+			u.recordFailure(fileResult, result, writeErr)
+			continue
+		}
+
+		result.IsUpdate = !isInserted
+		if isInserted {
+			fileResult.Inserted++
+		} else {
+			fileResult.Updated++
+		}
+		fileResult.Committed++
+	}
+	return writeErr
+}
+
+// errNotWritten fails a row that the upsert did not report.
+var errNotWritten = errors.New("not written by upsert")
+
+// applyErrorPolicy aborts the file once its failed rows break policy,
+// recording why. end is set once every row has been processed.
+func (u *csvProcessorUsecase) applyErrorPolicy(
+	fileResult *domain.FileResult,
+	policy domain.ErrorPolicy,
+	processedCount int,
+	end bool,
+	abort context.CancelFunc,
+) {
+	if fileResult.Aborted {
+		return
+	}
+	reason := policyBreach(policy, fileResult.Failed, processedCount, end)
+	if reason == "" {
+		return
+	}
+
+	abort()
+	fileResult.Aborted = true
+	fileResult.AbortReason = reason
+	errorMsg := "Aborted: " + reason
+	fileResult.Errors = append(fileResult.Errors, errorMsg)
+	u.logger.Error(errorMsg)
+}
+
+// recordFailure counts result as failed with err.
+func (u *csvProcessorUsecase) recordFailure(fileResult *domain.FileResult, result *domain.ProcessResult, err error) {
+	fileResult.Failed++
+	errorMsg := fmt.Sprintf("Row %d (SKU: %s): %v",
+		result.RowNumber, productName(result.Product), err)
+	fileResult.Errors = append(fileResult.Errors, errorMsg)
+	u.logger.Error(errorMsg)
+}
+
+// recordWarning reports a problem with result that did not fail the row.
+func (u *csvProcessorUsecase) recordWarning(fileResult *domain.FileResult, result *domain.ProcessResult) {
+	warningMsg := fmt.Sprintf("Row %d (SKU: %s): %s",
+		result.RowNumber, productName(result.Product), result.Warning)
+	fileResult.Errors = append(fileResult.Errors, warningMsg)
+	u.logger.Info(warningMsg)
+}
+
+// sendProgress reports progress against the byte offset reached in the file,
+// since the number of records is not known until the stream is exhausted.
+func (u *csvProcessorUsecase) sendProgress(
+	progressChan chan<- *domain.ProgressUpdate,
+	filePath string,
+	fileResult *domain.FileResult,
+	processedCount, readCount int,
+	bytesRead, totalBytes int64,
+) {
+	percentage := 100.0
+	if totalBytes > 0 {
+		percentage = float64(bytesRead) / float64(totalBytes) * 100
+	}
+	u.logger.Progress(filePath, processedCount, readCount, percentage)
+
+	if progressChan != nil {
+		progressChan <- &domain.ProgressUpdate{
+			FileName:       filePath,
+			TotalRecords:   readCount,
+			ProcessedCount: processedCount,
+			BytesRead:      bytesRead,
+			TotalBytes:     totalBytes,
+			Percentage:     percentage,
+			Inserted:       fileResult.Inserted,
+			Updated:        fileResult.Updated,
+			Failed:         fileResult.Failed,
+			Message:        fmt.Sprintf("Processing %s: %.2f%% complete", filePath, percentage),
+		}
+	}
+}
+
 func (u *csvProcessorUsecase) worker(
+	ctx context.Context,
 	id int,
+	settings importSettings,
 	jobs <-chan *domain.ProcessJob,
 	results chan<- *domain.ProcessResult,
 	wg *sync.WaitGroup,
@@ -187,52 +713,173 @@ func (u *csvProcessorUsecase) worker(
 	defer wg.Done()
 
 	for job := range jobs {
-		result := u.processRecord(job)
+		// Drain without processing once the run is cancelled
+		if ctx.Err() != nil {
+			continue
+		}
+		result := u.processRecord(job, settings)
 		results <- result
 	}
 }
 
-func (u *csvProcessorUsecase) processRecord(job *domain.ProcessJob) *domain.ProcessResult {
+func (u *csvProcessorUsecase) processRecord(job *domain.ProcessJob, settings importSettings) *domain.ProcessResult {
 	record := job.Record
-
-	// Convert CSV record to Product
-	product, err := u.convertToProduct(record)
-	if err != nil {
+	if job.Error != nil {
 		return &domain.ProcessResult{
-			Product:   product,
-			Error:     err,
+			Error:     job.Error,
 			RowNumber: record.RowNumber,
 			FilePath:  job.FilePath,
 		}
 	}
 
-	// Check if product exists
-	existing, err := u.repo.FindById(product.ID)
+	// The EAN, currency and availability are normalised before the rules
+	// see them; a rejected EAN is reported together with the row's other
+	// violations
+	warning, eanErr := normalizeEan(record, settings.ean)
+	record.Currency = currency.Normalize(record.Currency)
+	record.Availability = settings.availability.Normalize(record.Availability)
+
+	// Convert CSV record to Product
+	product, err := u.convertToProduct(record, settings.rules)
+	if eanErr != nil {
+		product, err = nil, withViolation(err, validator.FieldError{Field: "ean", Message: eanErr.Error()})
+	}
+	if err == nil {
+		var stockWarning string
+		if stockWarning, err = checkAvailability(product, settings.stock); err == nil {
+			warning = joinWarnings(warning, stockWarning)
+			err = settings.prices.convert(product)
+		}
+		if err != nil {
+			product = nil
+		}
+	}
 	if err != nil {
 		return &domain.ProcessResult{
 			Product:   product,
 			Error:     err,
+			Warning:   warning,
 			RowNumber: record.RowNumber,
 			FilePath:  job.FilePath,
 		}
 	}
 
-	isUpdate := false
-	if existing != nil {
-		product.ID = existing.ID
-		product.CreatedAt = existing.CreatedAt
-		isUpdate = true
-	}
-
+	// Inserts and updates are told apart per batch in upsertBatch
 	return &domain.ProcessResult{
 		Product:   product,
-		IsUpdate:  isUpdate,
+		Warning:   warning,
 		RowNumber: record.RowNumber,
 		FilePath:  job.FilePath,
 	}
 }
 
-func (u *csvProcessorUsecase) convertToProduct(record *domain.CSVRecord) (*domain.Product, error) {
+// normalizeEan checks the EAN of record under policy and replaces it with
+// its normalised form. An invalid code is returned as an error under the
+// reject strictness and as a warning under flag, where it is kept as is.
+func normalizeEan(record *domain.CSVRecord, policy gtin.Policy) (string, error) {
+	if !policy.Enabled() || record.Ean == "" {
+		return "", nil
+	}
+
+	ean, err := gtin.Normalize(record.Ean, policy.Format)
+	switch {
+	case err == nil:
+		record.Ean = ean
+		return "", nil
+	case policy.Strictness == gtin.StrictnessFlag:
+		return fmt.Sprintf("ean %q kept as is: %v", record.Ean, err), nil
+	default:
+		return "", fmt.Errorf("%q: %w", record.Ean, err)
+	}
+}
+
+// checkAvailability applies rule to the availability of product, deriving
+// an empty one from its stock. An availability contradicting the stock is
+// returned as a warning under the flag rule and as an error under reject.
+func checkAvailability(product *domain.Product, rule availability.StockRule) (string, error) {
+	if rule == "" || rule == availability.StockOff {
+		return "", nil
+	}
+	if product.Availability == "" {
+		product.Availability = availability.FromStock(product.Stock)
+		return "", nil
+	}
+	if rule == availability.StockDerive || !availability.Contradicts(product.Availability, product.Stock) {
+		return "", nil
+	}
+
+	if rule == availability.StockFlag {
+		return fmt.Sprintf("availability %s contradicts stock %d", product.Availability, product.Stock), nil
+	}
+	return "", validator.Errors{{Field: "availability", Message: fmt.Sprintf("%s contradicts stock %d", product.Availability, product.Stock)}}
+}
+
+// joinWarnings joins the non-empty warnings of a row.
+func joinWarnings(warnings ...string) string {
+	var joined []string
+	for _, warning := range warnings {
+		if warning != "" {
+			joined = append(joined, warning)
+		}
+	}
+	return strings.Join(joined, "; ")
+}
+
+// priceConversion converts prices into the base currency of the catalog.
+type priceConversion struct {
+	base string
+	// rates holds the value of one unit of each currency in base
+	rates map[string]float64
+}
+
+// convert converts the price of product into the base currency, keeping
+// the price as imported in OriginalPrice and OriginalCurrency. A price
+// without a currency is taken to be in the base currency. Nothing is
+// converted when c is nil.
+func (c *priceConversion) convert(product *domain.Product) error {
+	if c == nil {
+		return nil
+	}
+
+	price, from := product.Price, product.Currency
+	if from == "" {
+		from = c.base
+	}
+	if from != c.base {
+		rate, ok := c.rates[from]
+		if !ok {
+			return validator.Errors{{Field: "currency", Message: fmt.Sprintf("no exchange rate from %s to %s", from, c.base)}}
+		}
+		product.Price = math.Round(price*rate*100) / 100
+		if math.Abs(product.Price) > validator.MaxPrice {
+			return validator.Errors{{Field: "price", Message: fmt.Sprintf("%s %s in %s exceeds the column limit",
+				strconv.FormatFloat(price, 'f', -1, 64), from, c.base)}}
+		}
+	}
+
+	product.OriginalPrice = &price
+	product.OriginalCurrency = from
+	product.Currency = c.base
+	return nil
+}
+
+// withViolation adds violation to the rule violations in err, which may be
+// nil.
+func withViolation(err error, violation validator.FieldError) error {
+	var errs validator.Errors
+	if err != nil && !errors.As(err, &errs) {
+		return err
+	}
+	return append(errs, violation)
+}
+
+// convertToProduct validates record against rules, reporting every
+// violation together, and converts it into a product.
+func (u *csvProcessorUsecase) convertToProduct(record *domain.CSVRecord, rules *validator.Validator) (*domain.Product, error) {
+	if err := rules.Validate(record); err != nil {
+		return nil, err
+	}
+
 	price, err := strconv.ParseFloat(record.Price, 64)
 	if err != nil {
 		return nil, fmt.Errorf("invalid price: %v", err)
@@ -245,16 +892,12 @@ func (u *csvProcessorUsecase) convertToProduct(record *domain.CSVRecord) (*domai
 
 	id, err := strconv.Atoi(record.ID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid stock: %v", err)
+		return nil, fmt.Errorf("invalid id: %v", err)
 	}
 
 	internalId, err := strconv.Atoi(record.InternalId)
 	if err != nil {
-		return nil, fmt.Errorf("invalid stock: %v", err)
-	}
-
-	if record.Name == "" {
-		return nil, fmt.Errorf("SKU and Name are required")
+		return nil, fmt.Errorf("invalid internal id: %v", err)
 	}
 
 	return &domain.Product{
@@ -267,10 +910,17 @@ func (u *csvProcessorUsecase) convertToProduct(record *domain.CSVRecord) (*domai
 		Ean:          record.Ean,
 		Color:        record.Color,
 		Size:         record.Size,
-		Availability: record.Availability,
+		Availability: domain.Availability(record.Availability),
 		InternalId:   internalId,
 		Price:        price,
 		Stock:        stock,
 		CreatedBy:    "system",
 	}, nil
 }
+
+func productName(product *domain.Product) string {
+	if product == nil {
+		return ""
+	}
+	return product.Name
+}
@@ -4,24 +4,881 @@
 package usecase
 
 import (
+	"archive/zip"
+	"context"
 	"data-processing/internal/domain"
+	"data-processing/pkg/availability"
+	"data-processing/pkg/csv"
+	"data-processing/pkg/gtin"
+	"data-processing/pkg/sandbox"
+	"data-processing/pkg/validator"
 	"errors"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
+// writeProductsCSV writes /products.csv into a fresh import directory.
+func writeProductsCSV(t *testing.T, content string) *sandbox.Root {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "products.csv"), []byte(content), 0o644))
+
+	root, err := sandbox.NewRoot(dir)
+	require.NoError(t, err)
+	return root
+}
+
 func TestNewCSVProcessorUsecase(t *testing.T) {
 	mockRepo := domain.NewMockProductRepository(t)
+	mockProfileRepo := domain.NewMockImportProfileRepository(t)
+	mockRateRepo := domain.NewMockExchangeRateRepository(t)
 	mockLogger := domain.NewMockLogger(t)
 
-	usecase := NewCSVProcessorUsecase(mockRepo, mockLogger, 4, 100)
+	root, err := sandbox.NewRoot(t.TempDir())
+	require.NoError(t, err)
+
+	usecase := NewCSVProcessorUsecase(mockRepo, mockProfileRepo, mockRateRepo, csv.NewReader(nil), root, mockLogger, 4, 100, "EUR")
 
 	assert.NotNil(t, usecase)
 	assert.Implements(t, (*domain.CSVProcessorUsecase)(nil), usecase)
 }
 
+func TestProcessCSVFiles_Cancelled(t *testing.T) {
+	mockLogger := domain.NewMockLogger(t)
+	u := &csvProcessorUsecase{logger: mockLogger}
+
+	control := newRunControl()
+	control.cancel()
+	mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+
+	result, err := u.ProcessCSVFiles(context.Background(), []string{"/csv/a.csv"}, domain.ImportOptions{Control: control}, nil)
+
+	assert.NoError(t, err)
+	assert.True(t, result.Cancelled)
+	assert.Equal(t, domain.ErrJobCancelled.Error(), result.CancelReason)
+	assert.Empty(t, result.FileResults)
+}
+
+func TestProcessCSVFiles_ContextCancelled(t *testing.T) {
+	t.Run("cancelled before start", func(t *testing.T) {
+		mockLogger := domain.NewMockLogger(t)
+		u := &csvProcessorUsecase{logger: mockLogger}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+
+		result, err := u.ProcessCSVFiles(ctx, []string{"/csv/a.csv"}, domain.ImportOptions{}, nil)
+
+		assert.NoError(t, err)
+		assert.True(t, result.Cancelled)
+		assert.Equal(t, "import job cancelled: context canceled", result.CancelReason)
+	})
+
+	t.Run("cancelled mid-file - reports committed rows", func(t *testing.T) {
+		root := writeProductsCSV(t, "Id,Name,Brand,Category,Price,Stock,Internal ID\n"+
+			"1,Fan,Brand,Category,10,5,7\n"+
+			"2,Mouse,Brand,Category,20,6,8\n"+
+			"3,Desk,Brand,Category,30,7,9\n")
+
+		mockRepo := domain.NewMockProductRepository(t)
+		mockLogger := domain.NewMockLogger(t)
+		u := &csvProcessorUsecase{
+			repo:        mockRepo,
+			logger:      mockLogger,
+			csvReader:   csv.NewReader(nil),
+			paths:       root,
+			workerCount: 1,
+			batchSize:   1,
+		}
+
+		ctx, cancel := context.WithCancelCause(context.Background())
+		defer cancel(nil)
+
+		// The first batch commits and cancels the run, later batches fail
+		// the way a cancelled query does
+		var upserts int
+		mockRepo.EXPECT().BulkUpsert(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, products []*domain.Product) ([]domain.UpsertOutcome, error) {
+				upserts++
+				if upserts == 1 {
+					cancel(domain.ErrJobTimeout)
+					return []domain.UpsertOutcome{{ID: products[0].ID, Inserted: true}}, nil
+				}
+				return nil, ctx.Err()
+			})
+		mockLogger.On("Info", mock.Anything, mock.Anything).Return().Maybe()
+		mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+		mockLogger.On("Error", mock.Anything, mock.Anything).Return().Maybe()
+		mockLogger.On("Progress", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+
+		result, err := u.ProcessCSVFiles(ctx, []string{"/products.csv"}, domain.ImportOptions{}, nil)
+
+		assert.NoError(t, err)
+		assert.True(t, result.Cancelled)
+		assert.Equal(t, domain.ErrJobTimeout.Error(), result.CancelReason)
+		assert.Equal(t, 1, result.Committed)
+		assert.Equal(t, 1, result.FileResults["/products.csv"].Committed)
+	})
+}
+
+func TestProcessCSVFiles_RepeatedID(t *testing.T) {
+	root := writeProductsCSV(t, "Id,Name,Brand,Category,Price,Stock,Internal ID\n"+
+		"1,Fan,Brand,Category,10,5,7\n"+
+		"1,Fan v2,Brand,Category,12,5,7\n")
+
+	mockRepo := domain.NewMockProductRepository(t)
+	mockLogger := domain.NewMockLogger(t)
+	u := &csvProcessorUsecase{
+		repo:        mockRepo,
+		logger:      mockLogger,
+		csvReader:   csv.NewReader(nil),
+		paths:       root,
+		workerCount: 2,
+		batchSize:   10,
+	}
+
+	// Each occurrence of the ID goes into its own statement
+	mockRepo.On("BulkUpsert", mock.Anything, mock.MatchedBy(func(products []*domain.Product) bool {
+		return len(products) == 1
+	})).Return([]domain.UpsertOutcome{{ID: 1, Inserted: true}}, nil).Once()
+	mockRepo.On("BulkUpsert", mock.Anything, mock.MatchedBy(func(products []*domain.Product) bool {
+		return len(products) == 1
+	})).Return([]domain.UpsertOutcome{{ID: 1, Inserted: false}}, nil).Once()
+	mockLogger.On("Info", mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Progress", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+
+	result, err := u.ProcessCSVFiles(context.Background(), []string{"/products.csv"}, domain.ImportOptions{}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Inserted)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, 2, result.Committed)
+}
+
+func TestProcessCSVFiles_Archive(t *testing.T) {
+	dir := t.TempDir()
+	file, err := os.Create(filepath.Join(dir, "products.zip"))
+	require.NoError(t, err)
+	archive := zip.NewWriter(file)
+	for name, content := range map[string]string{
+		"a.csv":     "Id,Name,Price,Stock,Internal ID\n1,Fan,10,5,7\n",
+		"b/b.csv":   "Id,Name,Price,Stock,Internal ID\n2,Mouse,20,6,8\n",
+		"notes.txt": "ignored",
+	} {
+		entry, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = entry.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	require.NoError(t, file.Close())
+
+	root, err := sandbox.NewRoot(dir)
+	require.NoError(t, err)
+
+	mockRepo := domain.NewMockProductRepository(t)
+	mockLogger := domain.NewMockLogger(t)
+	u := &csvProcessorUsecase{
+		repo:        mockRepo,
+		logger:      mockLogger,
+		csvReader:   csv.NewReader(nil),
+		paths:       root,
+		workerCount: 1,
+		batchSize:   10,
+	}
+
+	mockRepo.EXPECT().BulkUpsert(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, products []*domain.Product) ([]domain.UpsertOutcome, error) {
+			return []domain.UpsertOutcome{{ID: products[0].ID, Inserted: true}}, nil
+		}).Twice()
+	mockLogger.On("Info", mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Progress", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+
+	result, err := u.ProcessCSVFiles(context.Background(), []string{"/products.zip"}, domain.ImportOptions{}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Inserted)
+	assert.Len(t, result.FileResults, 2)
+	assert.Equal(t, 1, result.FileResults["/products.zip!/a.csv"].Inserted)
+	assert.Equal(t, 1, result.FileResults["/products.zip!/b/b.csv"].Inserted)
+}
+
+func TestProcessCSVFiles_JSON(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "feed.ndjson"), []byte(
+		`{"id": 1, "name": "Fan", "price": 10, "stock": 5, "internal_id": 7}`+"\n"+
+			`{"id": 2, "name": "Mouse", "price": "n/a", "stock": 6, "internal_id": 8}`+"\n"), 0o644))
+	root, err := sandbox.NewRoot(dir)
+	require.NoError(t, err)
+
+	mockRepo := domain.NewMockProductRepository(t)
+	mockLogger := domain.NewMockLogger(t)
+	u := &csvProcessorUsecase{
+		repo:        mockRepo,
+		logger:      mockLogger,
+		csvReader:   csv.NewReader(nil),
+		paths:       root,
+		workerCount: 1,
+		batchSize:   10,
+	}
+
+	mockRepo.On("BulkUpsert", mock.Anything, mock.MatchedBy(func(products []*domain.Product) bool {
+		return len(products) == 1 && products[0].ID == 1
+	})).Return([]domain.UpsertOutcome{{ID: 1, Inserted: true}}, nil).Once()
+	mockLogger.On("Info", mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Error", mock.Anything).Return().Maybe()
+	mockLogger.On("Progress", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+
+	result, err := u.ProcessCSVFiles(context.Background(), []string{"/feed.ndjson"}, domain.ImportOptions{}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.TotalRecords)
+	assert.Equal(t, 1, result.Inserted)
+	assert.Equal(t, 1, result.Failed)
+	assert.Contains(t, result.Errors[0], "Row 2")
+}
+
+func TestProcessCSVFiles_ReadError(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "feed.json"), []byte(
+		`[{"id": 1, "name": "Fan", "price": 10, "stock": 5, "internal_id": 7},`+
+			`{"id": 2, "name": "Mouse", "price": 20, "stock": 6, "internal_id": 8},`+
+			`{"id": 3, "name": `), 0o644))
+	root, err := sandbox.NewRoot(dir)
+	require.NoError(t, err)
+
+	mockRepo := domain.NewMockProductRepository(t)
+	mockLogger := domain.NewMockLogger(t)
+	u := &csvProcessorUsecase{
+		repo:        mockRepo,
+		logger:      mockLogger,
+		csvReader:   csv.NewReader(nil),
+		paths:       root,
+		workerCount: 1,
+		batchSize:   10,
+	}
+
+	mockRepo.On("BulkUpsert", mock.Anything, mock.MatchedBy(func(products []*domain.Product) bool {
+		return len(products) == 2
+	})).Return([]domain.UpsertOutcome{{ID: 1, Inserted: true}, {ID: 2, Inserted: true}}, nil).Once()
+	mockLogger.On("Info", mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+	mockLogger.On("Progress", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+
+	result, err := u.ProcessCSVFiles(context.Background(), []string{"/feed.json"}, domain.ImportOptions{}, nil)
+
+	assert.NoError(t, err)
+	fileResult := result.FileResults["/feed.json"]
+	assert.Contains(t, fileResult.Error, "Read aborted after 2 records")
+	assert.Equal(t, 2, result.Committed)
+	assert.Equal(t, 0, result.FilesProcessed())
+}
+
+func TestProcessCSVFiles_MalformedRows(t *testing.T) {
+	root := writeProductsCSV(t, "Id,Name,Price,Stock,Internal ID\n"+
+		"1,Fan,10,5,7\n"+
+		"2,Short\n"+
+		"3,\"Open,10,5,9\n"+
+		"4,Mouse,20,6,8\n")
+
+	mockRepo := domain.NewMockProductRepository(t)
+	mockLogger := domain.NewMockLogger(t)
+	u := &csvProcessorUsecase{
+		repo:        mockRepo,
+		logger:      mockLogger,
+		csvReader:   csv.NewReader(nil),
+		paths:       root,
+		workerCount: 1,
+		batchSize:   10,
+	}
+
+	mockRepo.On("BulkUpsert", mock.Anything, mock.MatchedBy(func(products []*domain.Product) bool {
+		return len(products) == 2 && products[0].ID == 1 && products[1].ID == 4
+	})).Return([]domain.UpsertOutcome{{ID: 1, Inserted: true}, {ID: 4, Inserted: true}}, nil).Once()
+	mockLogger.On("Info", mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Error", mock.Anything).Return().Maybe()
+	mockLogger.On("Progress", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+
+	result, err := u.ProcessCSVFiles(context.Background(), []string{"/products.csv"}, domain.ImportOptions{}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, result.TotalRecords)
+	assert.Equal(t, 2, result.Inserted)
+	assert.Equal(t, 2, result.Failed)
+	require.Len(t, result.Errors, 2)
+	assert.Contains(t, result.Errors[0], "Row 3")
+	assert.Contains(t, result.Errors[0], "wrong number of fields")
+	assert.Contains(t, result.Errors[1], "Row 4")
+}
+
+func TestProcessCSVFiles_ErrorPolicy(t *testing.T) {
+	// Rows 2 and 4 fail on their price
+	const content = "Id,Name,Price,Stock,Internal ID\n" +
+		"1,Fan,10,5,7\n" +
+		"2,Lamp,cheap,5,8\n" +
+		"3,Desk,30,2,9\n" +
+		"4,Chair,free,1,10\n" +
+		"5,Mouse,20,6,11\n"
+
+	newUsecase := func(t *testing.T) (*csvProcessorUsecase, *domain.MockProductRepository) {
+		mockRepo := domain.NewMockProductRepository(t)
+		mockLogger := domain.NewMockLogger(t)
+		mockLogger.On("Info", mock.Anything, mock.Anything).Return().Maybe()
+		mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+		mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+		mockLogger.On("Error", mock.Anything).Return().Maybe()
+		mockLogger.On("Progress", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+
+		return &csvProcessorUsecase{
+			repo:        mockRepo,
+			logger:      mockLogger,
+			csvReader:   csv.NewReader(nil),
+			paths:       writeProductsCSV(t, content),
+			workerCount: 1,
+			batchSize:   1,
+		}, mockRepo
+	}
+	upserts := func(mockRepo *domain.MockProductRepository, ids ...int) {
+		for _, id := range ids {
+			mockRepo.On("BulkUpsert", mock.Anything, mock.MatchedBy(func(products []*domain.Product) bool {
+				return len(products) == 1 && products[0].ID == id
+			})).Return([]domain.UpsertOutcome{{ID: id, Inserted: true}}, nil).Once()
+		}
+	}
+
+	t.Run("success - fail fast aborts on the first failed row", func(t *testing.T) {
+		u, mockRepo := newUsecase(t)
+		upserts(mockRepo, 1)
+
+		result, err := u.ProcessCSVFiles(context.Background(), []string{"/products.csv"}, domain.ImportOptions{
+			ErrorPolicy: domain.ErrorPolicy{FailFast: true},
+		}, nil)
+
+		require.NoError(t, err)
+		fileResult := result.FileResults["/products.csv"]
+		assert.True(t, fileResult.Aborted)
+		assert.Equal(t, "fail fast on the first failed row", fileResult.AbortReason)
+		assert.Equal(t, 2, result.TotalRecords)
+		assert.Equal(t, 1, result.Inserted)
+		assert.Equal(t, 1, result.Failed)
+		assert.Equal(t, "Aborted: fail fast on the first failed row", result.Errors[len(result.Errors)-1])
+		assert.Equal(t, 0, result.FilesProcessed())
+	})
+
+	t.Run("success - max failures aborts at the limit", func(t *testing.T) {
+		u, mockRepo := newUsecase(t)
+		upserts(mockRepo, 1, 3)
+
+		result, err := u.ProcessCSVFiles(context.Background(), []string{"/products.csv"}, domain.ImportOptions{
+			ErrorPolicy: domain.ErrorPolicy{MaxFailures: 2},
+		}, nil)
+
+		require.NoError(t, err)
+		fileResult := result.FileResults["/products.csv"]
+		assert.True(t, fileResult.Aborted)
+		assert.Equal(t, "2 failed rows reached the limit of 2", fileResult.AbortReason)
+		assert.Equal(t, 4, result.TotalRecords)
+		assert.Equal(t, 2, result.Committed)
+	})
+
+	t.Run("success - failure rate of a short file judged at the end", func(t *testing.T) {
+		u, mockRepo := newUsecase(t)
+		upserts(mockRepo, 1, 3, 5)
+
+		result, err := u.ProcessCSVFiles(context.Background(), []string{"/products.csv"}, domain.ImportOptions{
+			ErrorPolicy: domain.ErrorPolicy{MaxFailureRate: 25},
+		}, nil)
+
+		require.NoError(t, err)
+		fileResult := result.FileResults["/products.csv"]
+		assert.True(t, fileResult.Aborted)
+		assert.Equal(t, "40.0% of 5 rows failed, above the limit of 25%", fileResult.AbortReason)
+		assert.Equal(t, 3, result.Committed)
+	})
+
+	t.Run("success - failures within the limits", func(t *testing.T) {
+		u, mockRepo := newUsecase(t)
+		upserts(mockRepo, 1, 3, 5)
+
+		result, err := u.ProcessCSVFiles(context.Background(), []string{"/products.csv"}, domain.ImportOptions{
+			ErrorPolicy: domain.ErrorPolicy{MaxFailures: 3, MaxFailureRate: 50},
+		}, nil)
+
+		require.NoError(t, err)
+		assert.False(t, result.FileResults["/products.csv"].Aborted)
+		assert.Equal(t, 3, result.Committed)
+		assert.Equal(t, 1, result.FilesProcessed())
+	})
+
+	t.Run("success - aborted file rolled back", func(t *testing.T) {
+		u, mockRepo := newUsecase(t)
+		upserts(mockRepo, 1)
+		mockRepo.EXPECT().Transaction(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, fn func(repo domain.ProductRepository) error) error {
+				return fn(mockRepo)
+			}).Once()
+
+		result, err := u.ProcessCSVFiles(context.Background(), []string{"/products.csv"}, domain.ImportOptions{
+			ErrorPolicy: domain.ErrorPolicy{FailFast: true, Rollback: true},
+		}, nil)
+
+		require.NoError(t, err)
+		fileResult := result.FileResults["/products.csv"]
+		assert.True(t, fileResult.Aborted)
+		assert.True(t, fileResult.RolledBack)
+		assert.Equal(t, 0, result.Inserted)
+		assert.Equal(t, 0, result.Committed)
+		assert.Equal(t, 1, result.Failed)
+	})
+
+	t.Run("success - file within the limits committed", func(t *testing.T) {
+		u, mockRepo := newUsecase(t)
+		upserts(mockRepo, 1, 3, 5)
+		mockRepo.EXPECT().Transaction(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, fn func(repo domain.ProductRepository) error) error {
+				return fn(mockRepo)
+			}).Once()
+
+		result, err := u.ProcessCSVFiles(context.Background(), []string{"/products.csv"}, domain.ImportOptions{
+			ErrorPolicy: domain.ErrorPolicy{MaxFailures: 3, Rollback: true},
+		}, nil)
+
+		require.NoError(t, err)
+		assert.False(t, result.FileResults["/products.csv"].RolledBack)
+		assert.Equal(t, 3, result.Committed)
+	})
+
+	t.Run("error - commit fails", func(t *testing.T) {
+		u, mockRepo := newUsecase(t)
+		upserts(mockRepo, 1, 3, 5)
+		mockRepo.EXPECT().Transaction(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, fn func(repo domain.ProductRepository) error) error {
+				if err := fn(mockRepo); err != nil {
+					return err
+				}
+				return errors.New("connection lost")
+			}).Once()
+		u.logger.(*domain.MockLogger).On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		result, err := u.ProcessCSVFiles(context.Background(), []string{"/products.csv"}, domain.ImportOptions{
+			ErrorPolicy: domain.ErrorPolicy{MaxFailures: 3, Rollback: true},
+		}, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "commit failed: connection lost", result.FileResults["/products.csv"].Error)
+		assert.Equal(t, 0, result.Committed)
+	})
+}
+
+func TestProcessCSVFiles_Atomic(t *testing.T) {
+	// Row 2 fails on its price
+	const content = "Id,Name,Price,Stock,Internal ID\n" +
+		"1,Fan,10,5,7\n" +
+		"2,Lamp,cheap,5,8\n" +
+		"3,Desk,30,2,9\n"
+
+	newUsecase := func(t *testing.T) (*csvProcessorUsecase, *domain.MockProductRepository) {
+		mockRepo := domain.NewMockProductRepository(t)
+		mockLogger := domain.NewMockLogger(t)
+		mockLogger.On("Info", mock.Anything, mock.Anything).Return().Maybe()
+		mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+		mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+		mockLogger.On("Error", mock.Anything).Return().Maybe()
+		mockLogger.On("Error", mock.Anything, mock.Anything).Return().Maybe()
+		mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+		mockLogger.On("Progress", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+
+		return &csvProcessorUsecase{
+			repo:        mockRepo,
+			logger:      mockLogger,
+			csvReader:   csv.NewReader(nil),
+			paths:       writeProductsCSV(t, content),
+			workerCount: 1,
+			batchSize:   10,
+		}, mockRepo
+	}
+	// upserts expects rows 1 and 3 to be written in one batch
+	upserts := func(mockRepo *domain.MockProductRepository) {
+		mockRepo.On("BulkUpsert", mock.Anything, mock.MatchedBy(func(products []*domain.Product) bool {
+			return len(products) == 2 && products[0].ID == 1 && products[1].ID == 3
+		})).Return([]domain.UpsertOutcome{{ID: 1, Inserted: true}, {ID: 3, Inserted: false}}, nil).Once()
+	}
+	// failWrite expects row 1 to be written and the write of row 3 to fail
+	failWrite := func(mockRepo *domain.MockProductRepository) {
+		mockRepo.On("BulkUpsert", mock.Anything, mock.MatchedBy(func(products []*domain.Product) bool {
+			return len(products) == 1 && products[0].ID == 1
+		})).Return([]domain.UpsertOutcome{{ID: 1, Inserted: true}}, nil).Once()
+		mockRepo.On("BulkUpsert", mock.Anything, mock.MatchedBy(func(products []*domain.Product) bool {
+			return len(products) == 1 && products[0].ID == 3
+		})).Return(nil, errors.New("connection lost")).Once()
+	}
+	// writeBrokenFeed writes /feed.json, which breaks off after rows 1 and 3
+	writeBrokenFeed := func(t *testing.T, u *csvProcessorUsecase) {
+		require.NoError(t, os.WriteFile(filepath.Join(u.paths.(*sandbox.Root).Dir(), "feed.json"), []byte(
+			`[{"id": 1, "name": "Fan", "price": 10, "stock": 5, "internal_id": 7},`+
+				`{"id": 3, "name": "Desk", "price": 30, "stock": 2, "internal_id": 9},`+
+				`{"id": 4, "name": `), 0o644))
+	}
+	inTransaction := func(mockRepo *domain.MockProductRepository, commitErr error) {
+		mockRepo.EXPECT().Transaction(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, fn func(repo domain.ProductRepository) error) error {
+				if err := fn(mockRepo); err != nil {
+					return err
+				}
+				return commitErr
+			}).Once()
+	}
+
+	t.Run("success - file committed as a whole", func(t *testing.T) {
+		u, mockRepo := newUsecase(t)
+		upserts(mockRepo)
+		inTransaction(mockRepo, nil)
+
+		result, err := u.ProcessCSVFiles(context.Background(), []string{"/products.csv"}, domain.ImportOptions{
+			Atomic: domain.AtomicFile,
+		}, nil)
+
+		require.NoError(t, err)
+		assert.False(t, result.RolledBack)
+		assert.Equal(t, 2, result.Committed)
+		assert.Equal(t, 1, result.Failed)
+	})
+
+	t.Run("success - request committed as a whole", func(t *testing.T) {
+		u, mockRepo := newUsecase(t)
+		upserts(mockRepo)
+		inTransaction(mockRepo, nil)
+
+		result, err := u.ProcessCSVFiles(context.Background(), []string{"/products.csv"}, domain.ImportOptions{
+			Atomic: domain.AtomicRequest,
+		}, nil)
+
+		require.NoError(t, err)
+		assert.False(t, result.RolledBack)
+		assert.Equal(t, 1, result.Inserted)
+		assert.Equal(t, 1, result.Updated)
+		assert.Equal(t, 2, result.Committed)
+	})
+
+	t.Run("success - request rolled back when a file cannot be processed", func(t *testing.T) {
+		u, mockRepo := newUsecase(t)
+		upserts(mockRepo)
+		inTransaction(mockRepo, nil)
+
+		result, err := u.ProcessCSVFiles(context.Background(), []string{"/products.csv", "/missing.csv"}, domain.ImportOptions{
+			Atomic: domain.AtomicRequest,
+		}, nil)
+
+		require.NoError(t, err)
+		assert.True(t, result.RolledBack)
+		assert.Contains(t, result.RollbackReason, "file /missing.csv failed: ")
+		assert.Equal(t, 3, result.TotalRecords)
+		assert.Equal(t, 0, result.Inserted)
+		assert.Equal(t, 0, result.Updated)
+		assert.Equal(t, 0, result.Committed)
+		assert.True(t, result.FileResults["/products.csv"].RolledBack)
+		assert.Equal(t, 0, result.FileResults["/products.csv"].Committed)
+		assert.Equal(t, "Rolled back every file: "+result.RollbackReason, result.Errors[len(result.Errors)-1])
+	})
+
+	t.Run("success - file rolled back when it breaks off", func(t *testing.T) {
+		u, mockRepo := newUsecase(t)
+		writeBrokenFeed(t, u)
+		upserts(mockRepo)
+		inTransaction(mockRepo, nil)
+
+		result, err := u.ProcessCSVFiles(context.Background(), []string{"/feed.json"}, domain.ImportOptions{
+			Atomic: domain.AtomicFile,
+		}, nil)
+
+		require.NoError(t, err)
+		fileResult := result.FileResults["/feed.json"]
+		assert.Contains(t, fileResult.Error, "Read aborted after 2 records")
+		assert.True(t, fileResult.RolledBack)
+		assert.Equal(t, 0, result.Inserted)
+		assert.Equal(t, 0, result.Committed)
+	})
+
+	t.Run("success - request rolled back when a file breaks off", func(t *testing.T) {
+		u, mockRepo := newUsecase(t)
+		writeBrokenFeed(t, u)
+		upserts(mockRepo)
+		inTransaction(mockRepo, nil)
+
+		result, err := u.ProcessCSVFiles(context.Background(), []string{"/feed.json", "/products.csv"}, domain.ImportOptions{
+			Atomic: domain.AtomicRequest,
+		}, nil)
+
+		require.NoError(t, err)
+		assert.True(t, result.RolledBack)
+		assert.Contains(t, result.RollbackReason, "file /feed.json failed: Read aborted after 2 records")
+		assert.True(t, result.FileResults["/feed.json"].RolledBack)
+		assert.NotContains(t, result.FileResults, "/products.csv")
+		assert.Equal(t, 0, result.Committed)
+	})
+
+	t.Run("success - file rolled back when a write fails", func(t *testing.T) {
+		u, mockRepo := newUsecase(t)
+		u.batchSize = 1
+		failWrite(mockRepo)
+		inTransaction(mockRepo, nil)
+
+		result, err := u.ProcessCSVFiles(context.Background(), []string{"/products.csv"}, domain.ImportOptions{
+			Atomic: domain.AtomicFile,
+		}, nil)
+
+		require.NoError(t, err)
+		fileResult := result.FileResults["/products.csv"]
+		assert.Equal(t, "Write failed after 3 records: connection lost", fileResult.Error)
+		assert.True(t, fileResult.RolledBack)
+		assert.Equal(t, 0, result.Inserted)
+		assert.Equal(t, 0, result.Committed)
+		assert.Equal(t, 0, result.FilesProcessed())
+	})
+
+	t.Run("success - request rolled back when a write fails", func(t *testing.T) {
+		u, mockRepo := newUsecase(t)
+		u.batchSize = 1
+		writeBrokenFeed(t, u)
+		failWrite(mockRepo)
+		inTransaction(mockRepo, nil)
+
+		result, err := u.ProcessCSVFiles(context.Background(), []string{"/products.csv", "/feed.json"}, domain.ImportOptions{
+			Atomic: domain.AtomicRequest,
+		}, nil)
+
+		require.NoError(t, err)
+		assert.True(t, result.RolledBack)
+		assert.Equal(t, "file /products.csv failed: Write failed after 3 records: connection lost", result.RollbackReason)
+		assert.True(t, result.FileResults["/products.csv"].RolledBack)
+		assert.NotContains(t, result.FileResults, "/feed.json")
+		assert.Equal(t, 0, result.Inserted)
+		assert.Equal(t, 0, result.Committed)
+	})
+
+	t.Run("error - commit fails", func(t *testing.T) {
+		u, mockRepo := newUsecase(t)
+		upserts(mockRepo)
+		inTransaction(mockRepo, errors.New("connection lost"))
+
+		result, err := u.ProcessCSVFiles(context.Background(), []string{"/products.csv"}, domain.ImportOptions{
+			Atomic: domain.AtomicRequest,
+		}, nil)
+
+		assert.EqualError(t, err, "atomic import failed: connection lost")
+		assert.Nil(t, result)
+	})
+}
+
+func TestProcessCSVFiles_MissingFile(t *testing.T) {
+	mockLogger := domain.NewMockLogger(t)
+	u := &csvProcessorUsecase{
+		logger:    mockLogger,
+		csvReader: csv.NewReader(nil),
+		paths:     writeProductsCSV(t, ""),
+	}
+
+	mockLogger.On("Info", mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return().Maybe()
+	mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	result, err := u.ProcessCSVFiles(context.Background(), []string{"/missing.csv"}, domain.ImportOptions{}, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Errors, 1)
+	assert.Contains(t, result.FileResults["/missing.csv"].Error, "no such file")
+	assert.Equal(t, 0, result.FilesProcessed())
+}
+
+func TestResolveOptions(t *testing.T) {
+	t.Run("success - no profile", func(t *testing.T) {
+		u := &csvProcessorUsecase{}
+
+		options, err := u.resolveOptions(domain.ImportOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, csv.Options{}, options.csv)
+		assert.Same(t, validator.Default(), options.rules)
+	})
+
+	t.Run("success - profile", func(t *testing.T) {
+		mockProfileRepo := domain.NewMockImportProfileRepository(t)
+		u := &csvProcessorUsecase{profileRepo: mockProfileRepo}
+
+		mockProfileRepo.On("FindByName", "supplier-eu").Return(&domain.ImportProfile{
+			Name:             "supplier-eu",
+			ColumnMapping:    domain.StringMap{"Artikel": "id"},
+			Delimiter:        ";",
+			Encoding:         "windows-1252",
+			DecimalSeparator: ",",
+			Transforms:       domain.FieldTransforms{"currency": {"trim", "upper"}},
+		}, nil)
+
+		options, err := u.resolveOptions(domain.ImportOptions{Profile: "supplier-eu"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, ';', options.csv.Delimiter)
+		assert.Equal(t, "windows-1252", options.csv.Encoding)
+		assert.Equal(t, ",", options.csv.DecimalSeparator)
+		assert.Equal(t, csv.ColumnID, options.csv.Aliases["Artikel"])
+		assert.Equal(t, []string{"trim", "upper"}, options.csv.Transforms[csv.ColumnCurrency])
+	})
+
+	t.Run("success - dialect overrides profile", func(t *testing.T) {
+		mockProfileRepo := domain.NewMockImportProfileRepository(t)
+		u := &csvProcessorUsecase{profileRepo: mockProfileRepo}
+
+		mockProfileRepo.On("FindByName", "supplier-eu").Return(&domain.ImportProfile{
+			Name:      "supplier-eu",
+			Delimiter: ";",
+			Quote:     "'",
+			Encoding:  "windows-1252",
+		}, nil)
+
+		lazyQuotes := true
+		options, err := u.resolveOptions(domain.ImportOptions{
+			Profile: "supplier-eu",
+			Dialect: domain.CSVDialect{Delimiter: "\t", Comment: "#", LazyQuotes: &lazyQuotes, Encoding: "auto"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, '\t', options.csv.Delimiter)
+		assert.Equal(t, '\'', options.csv.Quote)
+		assert.Equal(t, '#', options.csv.Comment)
+		assert.True(t, options.csv.LazyQuotes)
+		assert.Equal(t, "auto", options.csv.Encoding)
+	})
+
+	t.Run("success - dialect turns off lazy quotes", func(t *testing.T) {
+		mockProfileRepo := domain.NewMockImportProfileRepository(t)
+		u := &csvProcessorUsecase{profileRepo: mockProfileRepo}
+
+		mockProfileRepo.On("FindByName", "supplier-eu").Return(&domain.ImportProfile{
+			Name:       "supplier-eu",
+			LazyQuotes: true,
+		}, nil)
+
+		options, err := u.resolveOptions(domain.ImportOptions{Profile: "supplier-eu"})
+		assert.NoError(t, err)
+		assert.True(t, options.csv.LazyQuotes)
+
+		lazyQuotes := false
+		options, err = u.resolveOptions(domain.ImportOptions{
+			Profile: "supplier-eu",
+			Dialect: domain.CSVDialect{LazyQuotes: &lazyQuotes},
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, options.csv.LazyQuotes)
+	})
+
+	t.Run("error - invalid dialect", func(t *testing.T) {
+		u := &csvProcessorUsecase{}
+
+		_, err := u.resolveOptions(domain.ImportOptions{Dialect: domain.CSVDialect{Delimiter: ",", Quote: ","}})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidDialect)
+	})
+
+	t.Run("error - invalid error policy", func(t *testing.T) {
+		u := &csvProcessorUsecase{}
+
+		for _, policy := range []domain.ErrorPolicy{
+			{MaxFailures: -1},
+			{MaxFailureRate: 100},
+			{Rollback: true},
+		} {
+			_, err := u.resolveOptions(domain.ImportOptions{ErrorPolicy: policy})
+
+			assert.ErrorIs(t, err, domain.ErrInvalidErrorPolicy)
+		}
+	})
+
+	t.Run("error - invalid atomic scope", func(t *testing.T) {
+		u := &csvProcessorUsecase{}
+
+		_, err := u.resolveOptions(domain.ImportOptions{Atomic: "batch"})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidAtomicScope)
+	})
+
+	t.Run("error - profile not found", func(t *testing.T) {
+		mockProfileRepo := domain.NewMockImportProfileRepository(t)
+		u := &csvProcessorUsecase{profileRepo: mockProfileRepo}
+
+		mockProfileRepo.On("FindByName", "missing").Return(nil, nil)
+
+		_, err := u.resolveOptions(domain.ImportOptions{Profile: "missing"})
+
+		assert.ErrorIs(t, err, domain.ErrProfileNotFound)
+	})
+
+	t.Run("success - exchange rates loaded", func(t *testing.T) {
+		mockProfileRepo := domain.NewMockImportProfileRepository(t)
+		mockRateRepo := domain.NewMockExchangeRateRepository(t)
+		u := &csvProcessorUsecase{
+			profileRepo:  mockProfileRepo,
+			rateRepo:     mockRateRepo,
+			baseCurrency: "EUR",
+		}
+
+		mockProfileRepo.On("FindByName", "supplier-us").Return(&domain.ImportProfile{
+			Name:            "supplier-us",
+			ConvertCurrency: true,
+		}, nil)
+		mockRateRepo.On("FindByBase", "EUR").Return(map[string]float64{"USD": 0.92}, nil)
+
+		options, err := u.resolveOptions(domain.ImportOptions{Profile: "supplier-us"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &priceConversion{base: "EUR", rates: map[string]float64{"USD": 0.92}}, options.prices)
+	})
+
+	t.Run("error - conversion without base currency", func(t *testing.T) {
+		mockProfileRepo := domain.NewMockImportProfileRepository(t)
+		u := &csvProcessorUsecase{profileRepo: mockProfileRepo}
+
+		mockProfileRepo.On("FindByName", "supplier-us").Return(&domain.ImportProfile{
+			Name:            "supplier-us",
+			ConvertCurrency: true,
+		}, nil)
+
+		_, err := u.resolveOptions(domain.ImportOptions{Profile: "supplier-us"})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidProfile)
+	})
+
+	t.Run("error - invalid profile", func(t *testing.T) {
+		mockProfileRepo := domain.NewMockImportProfileRepository(t)
+		u := &csvProcessorUsecase{profileRepo: mockProfileRepo}
+
+		mockProfileRepo.On("FindByName", "broken").Return(&domain.ImportProfile{
+			Name:       "broken",
+			Transforms: domain.FieldTransforms{"name": {"reverse"}},
+		}, nil)
+
+		_, err := u.resolveOptions(domain.ImportOptions{Profile: "broken"})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidProfile)
+	})
+}
+
 func TestConvertToProduct(t *testing.T) {
 	mockRepo := domain.NewMockProductRepository(t)
 	mockLogger := domain.NewMockLogger(t)
@@ -44,12 +901,12 @@ func TestConvertToProduct(t *testing.T) {
 			Ean:          "1234567890",
 			Color:        "Red",
 			Size:         "M",
-			Availability: "In Stock",
+			Availability: "in_stock",
 			InternalId:   "100",
 			RowNumber:    1,
 		}
 
-		product, err := u.convertToProduct(record)
+		product, err := u.convertToProduct(record, validator.Default())
 
 		assert.NoError(t, err)
 		assert.NotNil(t, product)
@@ -64,7 +921,7 @@ func TestConvertToProduct(t *testing.T) {
 		assert.Equal(t, "1234567890", product.Ean)
 		assert.Equal(t, "Red", product.Color)
 		assert.Equal(t, "M", product.Size)
-		assert.Equal(t, "In Stock", product.Availability)
+		assert.Equal(t, domain.AvailabilityInStock, product.Availability)
 		assert.Equal(t, 100, product.InternalId)
 		assert.Equal(t, "system", product.CreatedBy)
 	})
@@ -80,11 +937,11 @@ func TestConvertToProduct(t *testing.T) {
 			InternalId: "100",
 		}
 
-		product, err := u.convertToProduct(record)
+		product, err := u.convertToProduct(record, validator.Default())
 
 		assert.Error(t, err)
 		assert.Nil(t, product)
-		assert.Contains(t, err.Error(), "invalid price")
+		assert.Contains(t, err.Error(), "price: must be a number")
 	})
 
 	t.Run("error - invalid stock", func(t *testing.T) {
@@ -98,11 +955,11 @@ func TestConvertToProduct(t *testing.T) {
 			InternalId: "100",
 		}
 
-		product, err := u.convertToProduct(record)
+		product, err := u.convertToProduct(record, validator.Default())
 
 		assert.Error(t, err)
 		assert.Nil(t, product)
-		assert.Contains(t, err.Error(), "invalid stock")
+		assert.Contains(t, err.Error(), "stock: must be a whole number")
 	})
 
 	t.Run("error - invalid id", func(t *testing.T) {
@@ -116,11 +973,11 @@ func TestConvertToProduct(t *testing.T) {
 			InternalId: "100",
 		}
 
-		product, err := u.convertToProduct(record)
+		product, err := u.convertToProduct(record, validator.Default())
 
 		assert.Error(t, err)
 		assert.Nil(t, product)
-		assert.Contains(t, err.Error(), "invalid stock")
+		assert.Contains(t, err.Error(), "id: must be a whole number")
 	})
 
 	t.Run("error - invalid internal id", func(t *testing.T) {
@@ -134,11 +991,11 @@ func TestConvertToProduct(t *testing.T) {
 			InternalId: "invalid",
 		}
 
-		product, err := u.convertToProduct(record)
+		product, err := u.convertToProduct(record, validator.Default())
 
 		assert.Error(t, err)
 		assert.Nil(t, product)
-		assert.Contains(t, err.Error(), "invalid stock")
+		assert.Contains(t, err.Error(), "internal_id: must be a whole number")
 	})
 
 	t.Run("error - empty name", func(t *testing.T) {
@@ -152,11 +1009,31 @@ func TestConvertToProduct(t *testing.T) {
 			InternalId: "100",
 		}
 
-		product, err := u.convertToProduct(record)
+		product, err := u.convertToProduct(record, validator.Default())
 
 		assert.Error(t, err)
 		assert.Nil(t, product)
-		assert.Contains(t, err.Error(), "required")
+		assert.Contains(t, err.Error(), "name: is required")
+	})
+
+	t.Run("error - every violation reported", func(t *testing.T) {
+		record := &domain.CSVRecord{
+			ID:         "1",
+			Name:       "",
+			Price:      "-1",
+			Stock:      "10",
+			Currency:   "GBP",
+			InternalId: "100",
+		}
+		rules, err := validator.New(domain.ValidationRules{
+			"currency": {Allowed: []string{"USD", "EUR"}},
+		})
+		require.NoError(t, err)
+
+		product, err := u.convertToProduct(record, rules)
+
+		assert.Nil(t, product)
+		assert.EqualError(t, err, "name: is required; price: must be at least 0; currency: must be one of USD, EUR")
 	})
 }
 
@@ -183,9 +1060,7 @@ func TestProcessRecord(t *testing.T) {
 			FilePath: "/test/file.csv",
 		}
 
-		mockRepo.On("FindById", 1).Return(nil, nil)
-
-		result := u.processRecord(job)
+		result := u.processRecord(job, importSettings{rules: validator.Default()})
 
 		assert.NotNil(t, result)
 		assert.NoError(t, result.Error)
@@ -196,7 +1071,7 @@ func TestProcessRecord(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
-	t.Run("success - existing product (update)", func(t *testing.T) {
+	t.Run("error - invalid conversion", func(t *testing.T) {
 		mockRepo := domain.NewMockProductRepository(t)
 		mockLogger := domain.NewMockLogger(t)
 		u := &csvProcessorUsecase{
@@ -207,73 +1082,309 @@ func TestProcessRecord(t *testing.T) {
 		job := &domain.ProcessJob{
 			Record: &domain.CSVRecord{
 				ID:         "1",
-				Name:       "Updated Product",
+				Name:       "Test Product",
 				Brand:      "Test Brand",
 				Category:   "Test Category",
-				Price:      "149.99",
-				Stock:      "20",
+				Price:      "invalid",
+				Stock:      "10",
 				InternalId: "100",
-				RowNumber:  2,
+				RowNumber:  3,
 			},
 			FilePath: "/test/file.csv",
 		}
 
-		existingProduct := &domain.Product{
-			ID:        1,
-			Name:      "Old Product",
-			Brand:     "Test Brand",
-			Category:  "Test Category",
-			Price:     99.99,
-			Stock:     10,
-			CreatedBy: "system",
+		result := u.processRecord(job, importSettings{rules: validator.Default()})
+
+		assert.NotNil(t, result)
+		assert.Error(t, result.Error)
+		assert.Contains(t, result.Error.Error(), "price: must be a number")
+		assert.Equal(t, 3, result.RowNumber)
+	})
+
+	t.Run("success - ean normalised", func(t *testing.T) {
+		u := &csvProcessorUsecase{}
+		job := &domain.ProcessJob{
+			Record: &domain.CSVRecord{
+				ID:         "1",
+				Name:       "Fan",
+				Price:      "10",
+				Stock:      "5",
+				InternalId: "7",
+				// UPC-A whose leading zero was lost
+				Ean:       "36000291452",
+				RowNumber: 2,
+			},
 		}
 
-		mockRepo.On("FindById", 1).Return(existingProduct, nil)
+		result := u.processRecord(job, importSettings{
+			rules: validator.Default(),
+			ean:   gtin.Policy{Strictness: gtin.StrictnessReject, Format: gtin.FormatGTIN14},
+		})
 
-		result := u.processRecord(job)
+		require.NoError(t, result.Error)
+		assert.Empty(t, result.Warning)
+		assert.Equal(t, "00036000291452", result.Product.Ean)
+	})
 
-		assert.NotNil(t, result)
-		assert.NoError(t, result.Error)
-		assert.True(t, result.IsUpdate)
-		assert.Equal(t, 2, result.RowNumber)
-		assert.Equal(t, "/test/file.csv", result.FilePath)
-		assert.NotNil(t, result.Product)
-		assert.Equal(t, existingProduct.ID, result.Product.ID)
-		assert.Equal(t, existingProduct.CreatedAt, result.Product.CreatedAt)
-		mockRepo.AssertExpectations(t)
+	t.Run("success - invalid ean flagged", func(t *testing.T) {
+		u := &csvProcessorUsecase{}
+		job := &domain.ProcessJob{
+			Record: &domain.CSVRecord{
+				ID:         "1",
+				Name:       "Fan",
+				Price:      "10",
+				Stock:      "5",
+				InternalId: "7",
+				Ean:        "4006381333932",
+				RowNumber:  2,
+			},
+		}
+
+		result := u.processRecord(job, importSettings{
+			rules: validator.Default(),
+			ean:   gtin.Policy{Strictness: gtin.StrictnessFlag, Format: gtin.FormatEAN13},
+		})
+
+		require.NoError(t, result.Error)
+		assert.Equal(t, "4006381333932", result.Product.Ean)
+		assert.Contains(t, result.Warning, "invalid check digit")
 	})
 
-	t.Run("error - invalid conversion", func(t *testing.T) {
-		mockRepo := domain.NewMockProductRepository(t)
-		mockLogger := domain.NewMockLogger(t)
-		u := &csvProcessorUsecase{
-			repo:   mockRepo,
-			logger: mockLogger,
+	t.Run("error - invalid ean rejected with other violations", func(t *testing.T) {
+		u := &csvProcessorUsecase{}
+		job := &domain.ProcessJob{
+			Record: &domain.CSVRecord{
+				ID:         "1",
+				Price:      "10",
+				Stock:      "5",
+				InternalId: "7",
+				Ean:        "4006381333932",
+				RowNumber:  2,
+			},
 		}
 
+		result := u.processRecord(job, importSettings{
+			rules: validator.Default(),
+			ean:   gtin.Policy{Strictness: gtin.StrictnessReject, Format: gtin.FormatEAN13},
+		})
+
+		assert.Nil(t, result.Product)
+		assert.EqualError(t, result.Error, `name: is required; ean: "4006381333932": invalid check digit`)
+	})
+
+	t.Run("success - availability synonym mapped", func(t *testing.T) {
+		u := &csvProcessorUsecase{}
+		job := &domain.ProcessJob{
+			Record: &domain.CSVRecord{
+				ID:           "1",
+				Name:         "Fan",
+				Price:        "10",
+				Stock:        "5",
+				InternalId:   "7",
+				Availability: "In Stock",
+				RowNumber:    2,
+			},
+		}
+
+		result := u.processRecord(job, importSettings{rules: validator.Default()})
+
+		require.NoError(t, result.Error)
+		assert.Equal(t, domain.AvailabilityInStock, result.Product.Availability)
+	})
+
+	t.Run("error - unknown availability", func(t *testing.T) {
+		u := &csvProcessorUsecase{}
+		job := &domain.ProcessJob{
+			Record: &domain.CSVRecord{
+				ID:           "1",
+				Name:         "Fan",
+				Price:        "10",
+				Stock:        "5",
+				InternalId:   "7",
+				Availability: "maybe",
+				RowNumber:    2,
+			},
+		}
+
+		result := u.processRecord(job, importSettings{rules: validator.Default()})
+
+		assert.Nil(t, result.Product)
+		assert.Contains(t, result.Error.Error(), "availability: must be one of in_stock")
+	})
+
+	t.Run("success - availability derived from stock", func(t *testing.T) {
+		u := &csvProcessorUsecase{}
 		job := &domain.ProcessJob{
 			Record: &domain.CSVRecord{
 				ID:         "1",
-				Name:       "Test Product",
-				Brand:      "Test Brand",
-				Category:   "Test Category",
-				Price:      "invalid",
-				Stock:      "10",
-				InternalId: "100",
-				RowNumber:  3,
+				Name:       "Fan",
+				Price:      "10",
+				Stock:      "0",
+				InternalId: "7",
+				RowNumber:  2,
 			},
-			FilePath: "/test/file.csv",
 		}
 
-		result := u.processRecord(job)
+		result := u.processRecord(job, importSettings{
+			rules: validator.Default(),
+			stock: availability.StockDerive,
+		})
 
-		assert.NotNil(t, result)
-		assert.Error(t, result.Error)
-		assert.Contains(t, result.Error.Error(), "invalid price")
-		assert.Equal(t, 3, result.RowNumber)
+		require.NoError(t, result.Error)
+		assert.Equal(t, domain.AvailabilityOutOfStock, result.Product.Availability)
+	})
+
+	t.Run("success - availability contradicting stock flagged", func(t *testing.T) {
+		u := &csvProcessorUsecase{}
+		job := &domain.ProcessJob{
+			Record: &domain.CSVRecord{
+				ID:           "1",
+				Name:         "Fan",
+				Price:        "10",
+				Stock:        "0",
+				InternalId:   "7",
+				Availability: "in_stock",
+				RowNumber:    2,
+			},
+		}
+
+		result := u.processRecord(job, importSettings{
+			rules: validator.Default(),
+			stock: availability.StockFlag,
+		})
+
+		require.NoError(t, result.Error)
+		assert.Equal(t, domain.AvailabilityInStock, result.Product.Availability)
+		assert.Equal(t, "availability in_stock contradicts stock 0", result.Warning)
+	})
+
+	t.Run("error - availability contradicting stock rejected", func(t *testing.T) {
+		u := &csvProcessorUsecase{}
+		job := &domain.ProcessJob{
+			Record: &domain.CSVRecord{
+				ID:           "1",
+				Name:         "Fan",
+				Price:        "10",
+				Stock:        "12",
+				InternalId:   "7",
+				Availability: "Sold out",
+				RowNumber:    2,
+			},
+		}
+
+		result := u.processRecord(job, importSettings{
+			rules: validator.Default(),
+			stock: availability.StockReject,
+		})
+
+		assert.Nil(t, result.Product)
+		assert.EqualError(t, result.Error, "availability: out_of_stock contradicts stock 12")
+	})
+
+	t.Run("success - price converted into base currency", func(t *testing.T) {
+		u := &csvProcessorUsecase{}
+		job := &domain.ProcessJob{
+			Record: &domain.CSVRecord{
+				ID:         "1",
+				Name:       "Fan",
+				Price:      "19.99",
+				Currency:   " usd",
+				Stock:      "5",
+				InternalId: "7",
+				RowNumber:  2,
+			},
+		}
+
+		result := u.processRecord(job, importSettings{
+			rules:  validator.Default(),
+			prices: &priceConversion{base: "EUR", rates: map[string]float64{"USD": 0.92}},
+		})
+
+		require.NoError(t, result.Error)
+		assert.Equal(t, 18.39, result.Product.Price)
+		assert.Equal(t, "EUR", result.Product.Currency)
+		assert.Equal(t, 19.99, *result.Product.OriginalPrice)
+		assert.Equal(t, "USD", result.Product.OriginalCurrency)
+	})
+
+	t.Run("success - price in base currency kept", func(t *testing.T) {
+		u := &csvProcessorUsecase{}
+		job := &domain.ProcessJob{
+			Record: &domain.CSVRecord{
+				ID:         "1",
+				Name:       "Fan",
+				Price:      "10",
+				Stock:      "5",
+				InternalId: "7",
+				RowNumber:  2,
+			},
+		}
+
+		result := u.processRecord(job, importSettings{
+			rules:  validator.Default(),
+			prices: &priceConversion{base: "EUR"},
+		})
+
+		require.NoError(t, result.Error)
+		assert.Equal(t, 10.0, result.Product.Price)
+		assert.Equal(t, "EUR", result.Product.Currency)
+		assert.Equal(t, 10.0, *result.Product.OriginalPrice)
+		assert.Equal(t, "EUR", result.Product.OriginalCurrency)
+	})
+
+	t.Run("error - no exchange rate", func(t *testing.T) {
+		u := &csvProcessorUsecase{}
+		job := &domain.ProcessJob{
+			Record: &domain.CSVRecord{
+				ID:         "1",
+				Name:       "Fan",
+				Price:      "10",
+				Currency:   "GBP",
+				Stock:      "5",
+				InternalId: "7",
+				RowNumber:  2,
+			},
+		}
+
+		result := u.processRecord(job, importSettings{
+			rules:  validator.Default(),
+			prices: &priceConversion{base: "EUR", rates: map[string]float64{"USD": 0.92}},
+		})
+
+		assert.Nil(t, result.Product)
+		assert.EqualError(t, result.Error, "currency: no exchange rate from GBP to EUR")
 	})
 
-	t.Run("error - repository error", func(t *testing.T) {
+	t.Run("error - invalid currency code", func(t *testing.T) {
+		u := &csvProcessorUsecase{}
+		job := &domain.ProcessJob{
+			Record: &domain.CSVRecord{
+				ID:         "1",
+				Name:       "Fan",
+				Price:      "10",
+				Currency:   "Euro",
+				Stock:      "5",
+				InternalId: "7",
+				RowNumber:  2,
+			},
+		}
+
+		result := u.processRecord(job, importSettings{rules: validator.Default()})
+
+		assert.Nil(t, result.Product)
+		assert.EqualError(t, result.Error, "currency: must be an ISO 4217 currency code")
+	})
+}
+
+func TestUpsertBatch(t *testing.T) {
+	newBatch := func() []*domain.ProcessResult {
+		return []*domain.ProcessResult{
+			{Product: &domain.Product{ID: 1, Name: "Updated Product"}, RowNumber: 2},
+			{Product: &domain.Product{ID: 2, Name: "New Product"}, RowNumber: 3},
+		}
+	}
+
+	t.Run("success - counts outcomes reported by the upsert", func(t *testing.T) {
 		mockRepo := domain.NewMockProductRepository(t)
 		mockLogger := domain.NewMockLogger(t)
 		u := &csvProcessorUsecase{
@@ -281,29 +1392,64 @@ func TestProcessRecord(t *testing.T) {
 			logger: mockLogger,
 		}
 
-		job := &domain.ProcessJob{
-			Record: &domain.CSVRecord{
-				ID:         "1",
-				Name:       "Test Product",
-				Brand:      "Test Brand",
-				Category:   "Test Category",
-				Price:      "99.99",
-				Stock:      "10",
-				InternalId: "100",
-				RowNumber:  4,
-			},
-			FilePath: "/test/file.csv",
+		batch := newBatch()
+		fileResult := &domain.FileResult{}
+
+		mockRepo.On("BulkUpsert", mock.Anything, []*domain.Product{batch[0].Product, batch[1].Product}).
+			Return([]domain.UpsertOutcome{{ID: 1, Inserted: false}, {ID: 2, Inserted: true}}, nil).Once()
+
+		err := u.upsertBatch(context.Background(), mockRepo, batch, fileResult)
+
+		assert.NoError(t, err)
+		assert.True(t, batch[0].IsUpdate)
+		assert.False(t, batch[1].IsUpdate)
+		assert.Equal(t, 1, fileResult.Updated)
+		assert.Equal(t, 1, fileResult.Inserted)
+		assert.Equal(t, 2, fileResult.Committed)
+		assert.Empty(t, fileResult.Errors)
+	})
+
+	t.Run("error - upsert fails every row", func(t *testing.T) {
+		mockRepo := domain.NewMockProductRepository(t)
+		mockLogger := domain.NewMockLogger(t)
+		u := &csvProcessorUsecase{
+			repo:   mockRepo,
+			logger: mockLogger,
 		}
 
-		mockRepo.On("FindById", 1).Return(nil, errors.New("database error"))
+		fileResult := &domain.FileResult{}
 
-		result := u.processRecord(job)
+		mockRepo.On("BulkUpsert", mock.Anything, mock.Anything).Return(nil, errors.New("database error"))
+		mockLogger.On("Error", mock.Anything, mock.Anything).Return()
+		mockLogger.On("Error", mock.Anything).Return()
 
-		assert.NotNil(t, result)
-		assert.Error(t, result.Error)
-		assert.Equal(t, "database error", result.Error.Error())
-		assert.Equal(t, 4, result.RowNumber)
-		mockRepo.AssertExpectations(t)
+		err := u.upsertBatch(context.Background(), mockRepo, newBatch(), fileResult)
+
+		assert.EqualError(t, err, "database error")
+		assert.Equal(t, 0, fileResult.Inserted)
+		assert.Equal(t, 0, fileResult.Updated)
+		assert.Equal(t, 2, fileResult.Failed)
+		assert.Equal(t, 0, fileResult.Committed)
+		assert.Equal(t, []string{
+			"Row 2 (SKU: Updated Product): database error",
+			"Row 3 (SKU: New Product): database error",
+		}, fileResult.Errors)
+	})
+
+	t.Run("error - cancelled upsert is not a failure", func(t *testing.T) {
+		mockRepo := domain.NewMockProductRepository(t)
+		u := &csvProcessorUsecase{repo: mockRepo}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		fileResult := &domain.FileResult{}
+
+		mockRepo.On("BulkUpsert", mock.Anything, mock.Anything).Return(nil, context.Canceled)
+
+		err := u.upsertBatch(ctx, mockRepo, newBatch(), fileResult)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.FileResult{}, *fileResult)
 	})
 }
 
@@ -346,16 +1492,13 @@ func TestWorker(t *testing.T) {
 		FilePath: "/test/file.csv",
 	}
 
-	mockRepo.On("FindById", 1).Return(nil, nil)
-	mockRepo.On("FindById", 2).Return(nil, nil)
-
 	jobs <- job1
 	jobs <- job2
 	close(jobs)
 
 	var wg sync.WaitGroup
 	wg.Add(1)
-	go u.worker(1, jobs, results, &wg)
+	go u.worker(context.Background(), 1, importSettings{rules: validator.Default()}, jobs, results, &wg)
 	wg.Wait()
 	close(results)
 
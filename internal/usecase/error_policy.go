@@ -0,0 +1,51 @@
+// ============================================
+// internal/usecase/error_policy.go
+// ============================================
+package usecase
+
+import (
+	"data-processing/internal/domain"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// minRateRows is how many rows a file must have before its failure rate
+// can abort it, so that a few early failures do not.
+const minRateRows = 100
+
+// validateErrorPolicy rejects limits that cannot be applied.
+func validateErrorPolicy(policy domain.ErrorPolicy) error {
+	switch {
+	case policy.MaxFailures < 0:
+		return fmt.Errorf("%w: max failures must not be negative", domain.ErrInvalidErrorPolicy)
+	case math.IsNaN(policy.MaxFailureRate) || policy.MaxFailureRate < 0 || policy.MaxFailureRate >= 100:
+		return fmt.Errorf("%w: max failure rate must be at least 0 and below 100", domain.ErrInvalidErrorPolicy)
+	case policy.Rollback && !policy.Enabled():
+		return fmt.Errorf("%w: rollback needs fail fast, max failures or max failure rate", domain.ErrInvalidErrorPolicy)
+	}
+	return nil
+}
+
+// policyBreach returns why failed of processed rows make policy abort a
+// file, or an empty string. The failure rate is judged from minRateRows
+// rows on, and at the end of the file.
+func policyBreach(policy domain.ErrorPolicy, failed, processed int, end bool) string {
+	switch {
+	case failed == 0:
+		return ""
+	case policy.FailFast:
+		return "fail fast on the first failed row"
+	case policy.MaxFailures > 0 && failed >= policy.MaxFailures:
+		return fmt.Sprintf("%d failed rows reached the limit of %d", failed, policy.MaxFailures)
+	}
+
+	if policy.MaxFailureRate > 0 && (end || processed >= minRateRows) {
+		rate := float64(failed) / float64(processed) * 100
+		if rate > policy.MaxFailureRate {
+			return fmt.Sprintf("%.1f%% of %d rows failed, above the limit of %s%%",
+				rate, processed, strconv.FormatFloat(policy.MaxFailureRate, 'f', -1, 64))
+		}
+	}
+	return ""
+}
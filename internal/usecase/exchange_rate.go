@@ -0,0 +1,46 @@
+// ============================================
+// internal/usecase/exchange_rate.go
+// ============================================
+package usecase
+
+import (
+	"data-processing/internal/domain"
+	"data-processing/pkg/currency"
+	"fmt"
+	"math"
+)
+
+// maxRate is the largest rate the exchange_rates table holds, DECIMAL(18, 8).
+const maxRate = 9999999999.99999999
+
+type exchangeRateUsecase struct {
+	repo domain.ExchangeRateRepository
+}
+
+func NewExchangeRateUsecase(repo domain.ExchangeRateRepository) domain.ExchangeRateUsecase {
+	return &exchangeRateUsecase{repo: repo}
+}
+
+func (u *exchangeRateUsecase) SaveRate(rate *domain.ExchangeRate) error {
+	rate.Currency = currency.Normalize(rate.Currency)
+	rate.BaseCurrency = currency.Normalize(rate.BaseCurrency)
+
+	switch {
+	case !currency.Valid(rate.Currency):
+		return fmt.Errorf("%w: unknown currency %q", domain.ErrInvalidExchangeRate, rate.Currency)
+	case !currency.Valid(rate.BaseCurrency):
+		return fmt.Errorf("%w: unknown base currency %q", domain.ErrInvalidExchangeRate, rate.BaseCurrency)
+	case rate.Currency == rate.BaseCurrency:
+		return fmt.Errorf("%w: currency and base currency are the same", domain.ErrInvalidExchangeRate)
+	case math.IsNaN(rate.Rate) || rate.Rate <= 0:
+		return fmt.Errorf("%w: rate must be greater than 0", domain.ErrInvalidExchangeRate)
+	case rate.Rate > maxRate:
+		return fmt.Errorf("%w: rate must be below 10000000000", domain.ErrInvalidExchangeRate)
+	}
+
+	return u.repo.Save(rate)
+}
+
+func (u *exchangeRateUsecase) ListRates() ([]*domain.ExchangeRate, error) {
+	return u.repo.GetAll()
+}
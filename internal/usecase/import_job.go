@@ -0,0 +1,326 @@
+// ============================================
+// internal/usecase/import_job.go
+// ============================================
+package usecase
+
+import (
+	"context"
+	"data-processing/internal/domain"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type importJobUsecase struct {
+	repo        domain.ImportJobRepository
+	profileRepo domain.ImportProfileRepository
+	processor   domain.CSVProcessorUsecase
+	paths       domain.PathResolver
+	logger      domain.Logger
+	queue       chan *domain.ImportJob
+	hub         *progressHub
+	controls    sync.Map
+	jobTimeout  time.Duration
+
+	// ctx is cancelled with domain.ErrShuttingDown on Shutdown, which
+	// cancels every running job
+	ctx     context.Context
+	stop    context.CancelCauseFunc
+	runners sync.WaitGroup
+}
+
+// NewImportJobUsecase creates the job usecase and starts runnerCount
+// goroutines that each process one queued job at a time. Each job is
+// cancelled once it has run for jobTimeout, or never when it is zero.
+func NewImportJobUsecase(
+	repo domain.ImportJobRepository,
+	profileRepo domain.ImportProfileRepository,
+	processor domain.CSVProcessorUsecase,
+	paths domain.PathResolver,
+	logger domain.Logger,
+	queueSize int,
+	runnerCount int,
+	jobTimeout time.Duration,
+) domain.ImportJobUsecase {
+	ctx, stop := context.WithCancelCause(context.Background())
+	u := &importJobUsecase{
+		repo:        repo,
+		profileRepo: profileRepo,
+		processor:   processor,
+		paths:       paths,
+		logger:      logger,
+		queue:       make(chan *domain.ImportJob, queueSize),
+		hub:         newProgressHub(),
+		jobTimeout:  jobTimeout,
+		ctx:         ctx,
+		stop:        stop,
+	}
+
+	for i := 0; i < runnerCount; i++ {
+		u.runners.Add(1)
+		go u.runner()
+	}
+
+	return u
+}
+
+func (u *importJobUsecase) Enqueue(filePaths []string, options domain.ImportOptions) (*domain.ImportJob, error) {
+	if u.ctx.Err() != nil {
+		return nil, domain.ErrQueueClosed
+	}
+
+	// Expand directories and patterns, rejecting paths outside the import
+	// directory, before queueing
+	filePaths, err := u.expandPaths(filePaths, options.Recursive)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reject unknown profiles, invalid dialects, error policies and atomic
+	// scopes before queueing
+	if _, err := importOptions(u.profileRepo, options); err != nil {
+		return nil, err
+	}
+
+	job := &domain.ImportJob{
+		ID:          uuid.NewString(),
+		Status:      domain.JobQueued,
+		FilePaths:   filePaths,
+		Profile:     options.Profile,
+		Dialect:     options.Dialect,
+		ErrorPolicy: options.ErrorPolicy,
+		Atomic:      options.Atomic,
+		CreatedAt:   time.Now(),
+	}
+	if err := u.repo.Create(job); err != nil {
+		return nil, err
+	}
+
+	// The runner owns job from here on, so hand the caller a copy
+	queued := *job
+
+	u.hub.register(job.ID)
+	u.controls.Store(job.ID, newRunControl())
+	select {
+	case u.queue <- job:
+	default:
+		u.finish(job, domain.JobFailed, domain.ErrQueueFull.Error())
+		return nil, domain.ErrQueueFull
+	}
+
+	u.logger.Info("Import job %s queued with %d files", queued.ID, len(filePaths))
+	return &queued, nil
+}
+
+// expandPaths expands every input in order, keeping the first occurrence
+// of a file named by more than one input.
+func (u *importJobUsecase) expandPaths(inputs []string, recursive bool) ([]string, error) {
+	var filePaths []string
+	seen := make(map[string]struct{})
+	for _, input := range inputs {
+		expanded, err := u.paths.Expand(input, recursive)
+		if err != nil {
+			return nil, err
+		}
+		for _, filePath := range expanded {
+			if _, ok := seen[filePath]; ok {
+				continue
+			}
+			seen[filePath] = struct{}{}
+			filePaths = append(filePaths, filePath)
+		}
+	}
+	return filePaths, nil
+}
+
+func (u *importJobUsecase) GetJob(id string) (*domain.ImportJob, error) {
+	job, err := u.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrJobNotFound, id)
+	}
+	return job, nil
+}
+
+func (u *importJobUsecase) ListJobs(status domain.JobStatus, limit int) ([]*domain.ImportJob, error) {
+	return u.repo.List(status, limit)
+}
+
+// Subscribe streams progress events for a job until it finishes. A job that
+// is not active in this process yields its current state as a single
+// summary event.
+func (u *importJobUsecase) Subscribe(id string) (<-chan *domain.JobEvent, func(), error) {
+	if events, unsubscribe, ok := u.hub.subscribe(id); ok {
+		return events, unsubscribe, nil
+	}
+
+	job, err := u.GetJob(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	events := make(chan *domain.JobEvent, 1)
+	events <- &domain.JobEvent{Type: domain.JobEventSummary, Job: job}
+	close(events)
+	return events, func() {}, nil
+}
+
+// Control pauses, resumes or cancels a queued or running job. Paused jobs
+// stop between rows; a cancelled job keeps the rows already committed.
+func (u *importJobUsecase) Control(id string, action domain.JobAction) error {
+	value, ok := u.controls.Load(id)
+	if !ok {
+		if _, err := u.GetJob(id); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: %s", domain.ErrJobNotActive, id)
+	}
+	control := value.(*runControl)
+
+	switch action {
+	case domain.JobActionPause:
+		control.pause()
+		u.hub.publish(id, &domain.JobEvent{Type: domain.JobEventPaused})
+	case domain.JobActionResume:
+		control.resume()
+		u.hub.publish(id, &domain.JobEvent{Type: domain.JobEventResumed})
+	case domain.JobActionCancel:
+		control.cancel()
+	default:
+		return fmt.Errorf("%w: %q", domain.ErrInvalidJobAction, action)
+	}
+
+	u.logger.Info("Import job %s: %s requested", id, action)
+	return nil
+}
+
+// Shutdown cancels running jobs with domain.ErrShuttingDown, marks queued
+// jobs cancelled and waits for the runners to save them, or for ctx to end.
+func (u *importJobUsecase) Shutdown(ctx context.Context) error {
+	u.stop(domain.ErrShuttingDown)
+
+	done := make(chan struct{})
+	go func() {
+		u.runners.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (u *importJobUsecase) runner() {
+	defer u.runners.Done()
+
+	for {
+		select {
+		case job := <-u.queue:
+			u.run(job)
+		case <-u.ctx.Done():
+			// Drain what is left so no job stays queued forever
+			for {
+				select {
+				case job := <-u.queue:
+					u.run(job)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (u *importJobUsecase) run(job *domain.ImportJob) {
+	ctx, cancel := context.WithCancelCause(u.ctx)
+	defer cancel(nil)
+	if u.jobTimeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeoutCause(ctx, u.jobTimeout, domain.ErrJobTimeout)
+		defer cancelTimeout()
+	}
+
+	control := u.control(job.ID)
+	control.bind(cancel)
+	if err := control.Checkpoint(ctx); err != nil {
+		u.finish(job, domain.JobCancelled, fmt.Sprintf("cancelled before start: %v", err))
+		return
+	}
+
+	startedAt := time.Now()
+	job.Status = domain.JobRunning
+	job.StartedAt = &startedAt
+	if err := u.repo.Update(job); err != nil {
+		u.logger.Error("Failed to mark import job %s running: %v", job.ID, err)
+	}
+
+	progressChan := make(chan *domain.ProgressUpdate, 100)
+	drained := make(chan struct{})
+	go func() {
+		for progress := range progressChan {
+			u.hub.publish(job.ID, &domain.JobEvent{
+				Type:     domain.JobEventProgress,
+				Progress: progress,
+			})
+		}
+		close(drained)
+	}()
+
+	result, err := u.processor.ProcessCSVFiles(ctx, job.FilePaths, domain.ImportOptions{
+		Profile:     job.Profile,
+		Control:     control,
+		Dialect:     job.Dialect,
+		ErrorPolicy: job.ErrorPolicy,
+		Atomic:      job.Atomic,
+	}, progressChan)
+	close(progressChan)
+	<-drained
+
+	switch {
+	case err != nil:
+		u.finish(job, domain.JobFailed, err.Error())
+	case result.Cancelled:
+		job.ApplyResult(result)
+		u.finish(job, domain.JobCancelled, result.CancelReason)
+	case result.RolledBack:
+		job.ApplyResult(result)
+		u.finish(job, domain.JobFailed, "rolled back: "+result.RollbackReason)
+	case result.FilesProcessed() == 0 && len(job.FilePaths) > 0:
+		job.ApplyResult(result)
+		u.finish(job, domain.JobFailed, "no files could be processed")
+	default:
+		job.ApplyResult(result)
+		u.finish(job, domain.JobSucceeded, "")
+	}
+}
+
+// control returns the run control registered for the job at enqueue time.
+func (u *importJobUsecase) control(jobID string) *runControl {
+	value, _ := u.controls.LoadOrStore(jobID, newRunControl())
+	return value.(*runControl)
+}
+
+// finish moves job to a terminal status and persists it.
+func (u *importJobUsecase) finish(job *domain.ImportJob, status domain.JobStatus, errorMsg string) {
+	finishedAt := time.Now()
+	job.Status = status
+	job.Error = errorMsg
+	job.FinishedAt = &finishedAt
+
+	if err := u.repo.Update(job); err != nil {
+		u.logger.Error("Failed to save import job %s: %v", job.ID, err)
+	} else {
+		u.logger.Info("Import job %s %s", job.ID, status)
+	}
+
+	u.controls.Delete(job.ID)
+	summary := *job
+	u.hub.finish(job.ID, &domain.JobEvent{Type: domain.JobEventSummary, Job: &summary})
+}
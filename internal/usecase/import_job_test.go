@@ -0,0 +1,548 @@
+// ============================================
+// internal/usecase/import_job_test.go
+// ============================================
+package usecase
+
+import (
+	"context"
+	"data-processing/internal/domain"
+	"data-processing/pkg/sandbox"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRoot(t *testing.T) *sandbox.Root {
+	root, err := sandbox.NewRoot(t.TempDir())
+	require.NoError(t, err)
+	return root
+}
+
+func TestImportJobUsecase_Enqueue(t *testing.T) {
+	t.Run("success - queued", func(t *testing.T) {
+		mockRepo := domain.NewMockImportJobRepository(t)
+		mockLogger := domain.NewMockLogger(t)
+		u := &importJobUsecase{
+			repo:   mockRepo,
+			logger: mockLogger,
+			queue:  make(chan *domain.ImportJob, 1),
+			paths:  newTestRoot(t),
+			hub:    newProgressHub(),
+			ctx:    context.Background(),
+		}
+
+		mockRepo.On("Create", mock.AnythingOfType("*domain.ImportJob")).Return(nil)
+		mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+
+		job, err := u.Enqueue([]string{"/csv/a.csv"}, domain.ImportOptions{})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, job.ID)
+		assert.Equal(t, domain.JobQueued, job.Status)
+		assert.Equal(t, domain.StringList{"/csv/a.csv"}, job.FilePaths)
+
+		queued := <-u.queue
+		assert.Equal(t, job.ID, queued.ID)
+	})
+
+	t.Run("error - profile not found", func(t *testing.T) {
+		mockRepo := domain.NewMockImportJobRepository(t)
+		mockProfileRepo := domain.NewMockImportProfileRepository(t)
+		u := &importJobUsecase{
+			repo:        mockRepo,
+			profileRepo: mockProfileRepo,
+			queue:       make(chan *domain.ImportJob, 1),
+			paths:       newTestRoot(t),
+			ctx:         context.Background(),
+		}
+
+		mockProfileRepo.On("FindByName", "missing").Return(nil, nil)
+
+		job, err := u.Enqueue([]string{"/csv/a.csv"}, domain.ImportOptions{Profile: "missing"})
+
+		assert.ErrorIs(t, err, domain.ErrProfileNotFound)
+		assert.Nil(t, job)
+	})
+
+	t.Run("error - invalid dialect", func(t *testing.T) {
+		mockRepo := domain.NewMockImportJobRepository(t)
+		u := &importJobUsecase{
+			repo:  mockRepo,
+			queue: make(chan *domain.ImportJob, 1),
+			paths: newTestRoot(t),
+			ctx:   context.Background(),
+		}
+
+		job, err := u.Enqueue([]string{"/csv/a.csv"}, domain.ImportOptions{
+			Dialect: domain.CSVDialect{Encoding: "klingon"},
+		})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidDialect)
+		assert.Nil(t, job)
+	})
+
+	t.Run("error - invalid atomic scope", func(t *testing.T) {
+		mockRepo := domain.NewMockImportJobRepository(t)
+		u := &importJobUsecase{
+			repo:  mockRepo,
+			queue: make(chan *domain.ImportJob, 1),
+			paths: newTestRoot(t),
+			ctx:   context.Background(),
+		}
+
+		job, err := u.Enqueue([]string{"/csv/a.csv"}, domain.ImportOptions{Atomic: "batch"})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidAtomicScope)
+		assert.Nil(t, job)
+	})
+
+	t.Run("error - queue full", func(t *testing.T) {
+		mockRepo := domain.NewMockImportJobRepository(t)
+		mockLogger := domain.NewMockLogger(t)
+		u := &importJobUsecase{
+			repo:   mockRepo,
+			logger: mockLogger,
+			queue:  make(chan *domain.ImportJob),
+			paths:  newTestRoot(t),
+			hub:    newProgressHub(),
+			ctx:    context.Background(),
+		}
+
+		mockRepo.On("Create", mock.AnythingOfType("*domain.ImportJob")).Return(nil)
+		mockRepo.On("Update", mock.MatchedBy(func(job *domain.ImportJob) bool {
+			return job.Status == domain.JobFailed
+		})).Return(nil)
+		mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+
+		job, err := u.Enqueue([]string{"/csv/a.csv"}, domain.ImportOptions{})
+
+		assert.ErrorIs(t, err, domain.ErrQueueFull)
+		assert.Nil(t, job)
+	})
+
+	t.Run("success - expands directories and patterns", func(t *testing.T) {
+		mockRepo := domain.NewMockImportJobRepository(t)
+		mockLogger := domain.NewMockLogger(t)
+		root := newTestRoot(t)
+		require.NoError(t, os.MkdirAll(filepath.Join(root.Dir(), "csv", "daily"), 0o755))
+		for _, name := range []string{"b.csv", "a.csv", "daily/c.csv"} {
+			require.NoError(t, os.WriteFile(filepath.Join(root.Dir(), "csv", filepath.FromSlash(name)), nil, 0o644))
+		}
+		u := &importJobUsecase{
+			repo:   mockRepo,
+			logger: mockLogger,
+			queue:  make(chan *domain.ImportJob, 1),
+			paths:  root,
+			hub:    newProgressHub(),
+			ctx:    context.Background(),
+		}
+
+		mockRepo.On("Create", mock.AnythingOfType("*domain.ImportJob")).Return(nil)
+		mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+
+		job, err := u.Enqueue([]string{"/csv/b.csv", "/csv/*.csv", "/csv/daily/"}, domain.ImportOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StringList{"/csv/b.csv", "/csv/a.csv", "/csv/daily/c.csv"}, job.FilePaths)
+	})
+
+	t.Run("error - path not allowed", func(t *testing.T) {
+		mockRepo := domain.NewMockImportJobRepository(t)
+		u := &importJobUsecase{
+			repo:  mockRepo,
+			queue: make(chan *domain.ImportJob, 1),
+			paths: newTestRoot(t),
+			ctx:   context.Background(),
+		}
+
+		job, err := u.Enqueue([]string{"/csv/a.csv", "/../../etc/passwd"}, domain.ImportOptions{})
+
+		assert.ErrorIs(t, err, domain.ErrPathNotAllowed)
+		assert.Nil(t, job)
+	})
+}
+
+func TestImportJobUsecase_Run(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockRepo := domain.NewMockImportJobRepository(t)
+		mockProcessor := domain.NewMockCSVProcessorUsecase(t)
+		mockLogger := domain.NewMockLogger(t)
+		u := &importJobUsecase{
+			repo:      mockRepo,
+			processor: mockProcessor,
+			logger:    mockLogger,
+			hub:       newProgressHub(),
+			ctx:       context.Background(),
+		}
+
+		job := &domain.ImportJob{ID: "job-1", Status: domain.JobQueued, FilePaths: []string{"/csv/a.csv"}}
+		result := &domain.FinalResult{
+			TotalRecords: 3,
+			Inserted:     2,
+			Updated:      1,
+			FileResults:  domain.FileResultMap{"/csv/a.csv": {TotalRecords: 3}},
+		}
+
+		mockRepo.On("Update", job).Return(nil).Twice()
+		mockProcessor.On("ProcessCSVFiles", mock.Anything, []string{"/csv/a.csv"}, mock.MatchedBy(func(options domain.ImportOptions) bool {
+			return options.Profile == "" && options.Control != nil
+		}), mock.Anything).
+			Return(result, nil)
+		mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+
+		u.run(job)
+
+		assert.Equal(t, domain.JobSucceeded, job.Status)
+		assert.Equal(t, 2, job.Inserted)
+		assert.Equal(t, 1, job.Updated)
+		assert.NotNil(t, job.StartedAt)
+		assert.NotNil(t, job.FinishedAt)
+		assert.Empty(t, job.Error)
+	})
+
+	t.Run("failed - processor error", func(t *testing.T) {
+		mockRepo := domain.NewMockImportJobRepository(t)
+		mockProcessor := domain.NewMockCSVProcessorUsecase(t)
+		mockLogger := domain.NewMockLogger(t)
+		u := &importJobUsecase{
+			repo:      mockRepo,
+			processor: mockProcessor,
+			logger:    mockLogger,
+			hub:       newProgressHub(),
+			ctx:       context.Background(),
+		}
+
+		job := &domain.ImportJob{ID: "job-2", FilePaths: []string{"/csv/a.csv"}, Profile: "supplier-eu"}
+
+		mockRepo.On("Update", job).Return(nil).Twice()
+		mockProcessor.On("ProcessCSVFiles", mock.Anything, []string{"/csv/a.csv"}, mock.MatchedBy(func(options domain.ImportOptions) bool {
+			return options.Profile == "supplier-eu"
+		}), mock.Anything).
+			Return(nil, errors.New("profile lookup failed"))
+		mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+
+		u.run(job)
+
+		assert.Equal(t, domain.JobFailed, job.Status)
+		assert.Equal(t, "profile lookup failed", job.Error)
+	})
+
+	t.Run("failed - no files processed", func(t *testing.T) {
+		mockRepo := domain.NewMockImportJobRepository(t)
+		mockProcessor := domain.NewMockCSVProcessorUsecase(t)
+		mockLogger := domain.NewMockLogger(t)
+		u := &importJobUsecase{
+			repo:      mockRepo,
+			processor: mockProcessor,
+			logger:    mockLogger,
+			hub:       newProgressHub(),
+			ctx:       context.Background(),
+		}
+
+		job := &domain.ImportJob{ID: "job-3", FilePaths: []string{"/csv/missing.csv"}}
+		result := &domain.FinalResult{
+			Errors:      []string{"File /csv/missing.csv: no such file or directory"},
+			FileResults: domain.FileResultMap{},
+		}
+
+		mockRepo.On("Update", job).Return(nil).Twice()
+		mockProcessor.On("ProcessCSVFiles", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(result, nil)
+		mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+
+		u.run(job)
+
+		assert.Equal(t, domain.JobFailed, job.Status)
+		assert.Equal(t, domain.StringList{"File /csv/missing.csv: no such file or directory"}, job.Errors)
+	})
+
+	t.Run("failed - atomic request rolled back", func(t *testing.T) {
+		mockRepo := domain.NewMockImportJobRepository(t)
+		mockProcessor := domain.NewMockCSVProcessorUsecase(t)
+		mockLogger := domain.NewMockLogger(t)
+		u := &importJobUsecase{
+			repo:      mockRepo,
+			processor: mockProcessor,
+			logger:    mockLogger,
+			hub:       newProgressHub(),
+			ctx:       context.Background(),
+		}
+
+		job := &domain.ImportJob{ID: "job-4", FilePaths: []string{"/csv/a.csv"}, Atomic: domain.AtomicRequest}
+		result := &domain.FinalResult{
+			TotalRecords:   3,
+			Failed:         1,
+			RolledBack:     true,
+			RollbackReason: "file /csv/a.csv was aborted",
+			FileResults:    domain.FileResultMap{"/csv/a.csv": {TotalRecords: 3, Failed: 1, Aborted: true, RolledBack: true}},
+		}
+
+		mockRepo.On("Update", job).Return(nil).Twice()
+		mockProcessor.On("ProcessCSVFiles", mock.Anything, mock.Anything, mock.MatchedBy(func(options domain.ImportOptions) bool {
+			return options.Atomic == domain.AtomicRequest
+		}), mock.Anything).Return(result, nil)
+		mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+
+		u.run(job)
+
+		assert.Equal(t, domain.JobFailed, job.Status)
+		assert.Equal(t, "rolled back: file /csv/a.csv was aborted", job.Error)
+		assert.Equal(t, 3, job.TotalRecords)
+		assert.Equal(t, 0, job.Committed)
+	})
+}
+
+func TestImportJobUsecase_RunCancelled(t *testing.T) {
+	t.Run("cancelled before start", func(t *testing.T) {
+		mockRepo := domain.NewMockImportJobRepository(t)
+		mockLogger := domain.NewMockLogger(t)
+		u := &importJobUsecase{
+			repo:   mockRepo,
+			logger: mockLogger,
+			hub:    newProgressHub(),
+			ctx:    context.Background(),
+		}
+
+		job := &domain.ImportJob{ID: "job-1", Status: domain.JobQueued}
+		u.controls.Store(job.ID, newRunControl())
+
+		mockRepo.On("Update", job).Return(nil).Once()
+		mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+
+		assert.NoError(t, u.Control(job.ID, domain.JobActionCancel))
+		u.run(job)
+
+		assert.Equal(t, domain.JobCancelled, job.Status)
+		assert.Nil(t, job.StartedAt)
+	})
+
+	t.Run("cancelled while running", func(t *testing.T) {
+		mockRepo := domain.NewMockImportJobRepository(t)
+		mockProcessor := domain.NewMockCSVProcessorUsecase(t)
+		mockLogger := domain.NewMockLogger(t)
+		u := &importJobUsecase{
+			repo:      mockRepo,
+			processor: mockProcessor,
+			logger:    mockLogger,
+			hub:       newProgressHub(),
+			ctx:       context.Background(),
+		}
+
+		job := &domain.ImportJob{ID: "job-2", FilePaths: []string{"/csv/a.csv"}}
+		result := &domain.FinalResult{
+			Inserted:    5,
+			Cancelled:   true,
+			FileResults: domain.FileResultMap{"/csv/a.csv": {Inserted: 5}},
+		}
+
+		mockRepo.On("Update", job).Return(nil).Twice()
+		mockProcessor.On("ProcessCSVFiles", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(result, nil)
+		mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+
+		u.run(job)
+
+		assert.Equal(t, domain.JobCancelled, job.Status)
+		assert.Equal(t, 5, job.Inserted)
+	})
+
+	t.Run("cancelled - deadline exceeded", func(t *testing.T) {
+		mockRepo := domain.NewMockImportJobRepository(t)
+		mockProcessor := domain.NewMockCSVProcessorUsecase(t)
+		mockLogger := domain.NewMockLogger(t)
+		u := &importJobUsecase{
+			repo:       mockRepo,
+			processor:  mockProcessor,
+			logger:     mockLogger,
+			hub:        newProgressHub(),
+			ctx:        context.Background(),
+			jobTimeout: 10 * time.Millisecond,
+		}
+
+		job := &domain.ImportJob{ID: "job-3", FilePaths: []string{"/csv/a.csv"}}
+
+		mockRepo.On("Update", job).Return(nil).Twice()
+		mockProcessor.EXPECT().ProcessCSVFiles(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, filePaths []string, options domain.ImportOptions, progressChan chan<- *domain.ProgressUpdate) (*domain.FinalResult, error) {
+				err := options.Control.Checkpoint(ctx)
+				for err == nil {
+					time.Sleep(time.Millisecond)
+					err = options.Control.Checkpoint(ctx)
+				}
+				return &domain.FinalResult{
+					Committed:    2,
+					Cancelled:    true,
+					CancelReason: err.Error(),
+					FileResults:  domain.FileResultMap{"/csv/a.csv": {Committed: 2}},
+				}, nil
+			})
+		mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+
+		u.run(job)
+
+		assert.Equal(t, domain.JobCancelled, job.Status)
+		assert.Equal(t, domain.ErrJobTimeout.Error(), job.Error)
+		assert.Equal(t, 2, job.Committed)
+	})
+}
+
+func TestImportJobUsecase_Shutdown(t *testing.T) {
+	mockRepo := domain.NewMockImportJobRepository(t)
+	mockProcessor := domain.NewMockCSVProcessorUsecase(t)
+	mockLogger := domain.NewMockLogger(t)
+	u := NewImportJobUsecase(mockRepo, nil, mockProcessor, newTestRoot(t), mockLogger, 1, 1, 0)
+
+	started := make(chan struct{})
+	finished := make(chan *domain.ImportJob, 1)
+	mockRepo.On("Create", mock.AnythingOfType("*domain.ImportJob")).Return(nil)
+	mockRepo.On("Update", mock.AnythingOfType("*domain.ImportJob")).
+		Run(func(args mock.Arguments) {
+			if job := args.Get(0).(*domain.ImportJob); job.Finished() {
+				finished <- job
+			}
+		}).
+		Return(nil)
+	mockProcessor.EXPECT().ProcessCSVFiles(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, filePaths []string, options domain.ImportOptions, progressChan chan<- *domain.ProgressUpdate) (*domain.FinalResult, error) {
+			close(started)
+			<-ctx.Done()
+			return &domain.FinalResult{
+				Cancelled:    true,
+				CancelReason: options.Control.Checkpoint(ctx).Error(),
+				FileResults:  domain.FileResultMap{},
+			}, nil
+		})
+	mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+	mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	_, err := u.Enqueue([]string{"/csv/a.csv"}, domain.ImportOptions{})
+	assert.NoError(t, err)
+	<-started
+
+	assert.NoError(t, u.Shutdown(context.Background()))
+
+	job := <-finished
+	assert.Equal(t, domain.JobCancelled, job.Status)
+	assert.Equal(t, domain.ErrShuttingDown.Error(), job.Error)
+
+	_, err = u.Enqueue([]string{"/csv/b.csv"}, domain.ImportOptions{})
+	assert.ErrorIs(t, err, domain.ErrQueueClosed)
+}
+
+func TestImportJobUsecase_Control(t *testing.T) {
+	t.Run("success - pause and resume", func(t *testing.T) {
+		mockLogger := domain.NewMockLogger(t)
+		u := &importJobUsecase{logger: mockLogger, hub: newProgressHub()}
+
+		control := newRunControl()
+		u.controls.Store("job-1", control)
+		mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+
+		assert.NoError(t, u.Control("job-1", domain.JobActionPause))
+
+		resumed := make(chan error)
+		go func() {
+			resumed <- control.Checkpoint(context.Background())
+		}()
+
+		assert.NoError(t, u.Control("job-1", domain.JobActionResume))
+		assert.NoError(t, <-resumed)
+	})
+
+	t.Run("error - invalid action", func(t *testing.T) {
+		u := &importJobUsecase{hub: newProgressHub()}
+		u.controls.Store("job-1", newRunControl())
+
+		err := u.Control("job-1", "restart")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidJobAction)
+	})
+
+	t.Run("error - job not active", func(t *testing.T) {
+		mockRepo := domain.NewMockImportJobRepository(t)
+		u := &importJobUsecase{repo: mockRepo, hub: newProgressHub()}
+
+		mockRepo.On("FindByID", "job-1").Return(&domain.ImportJob{ID: "job-1", Status: domain.JobSucceeded}, nil)
+
+		err := u.Control("job-1", domain.JobActionPause)
+
+		assert.ErrorIs(t, err, domain.ErrJobNotActive)
+	})
+}
+
+func TestImportJobUsecase_Subscribe(t *testing.T) {
+	t.Run("success - active job streams progress and summary", func(t *testing.T) {
+		mockRepo := domain.NewMockImportJobRepository(t)
+		mockProcessor := domain.NewMockCSVProcessorUsecase(t)
+		mockLogger := domain.NewMockLogger(t)
+		u := &importJobUsecase{
+			repo:      mockRepo,
+			processor: mockProcessor,
+			logger:    mockLogger,
+			hub:       newProgressHub(),
+			ctx:       context.Background(),
+		}
+
+		job := &domain.ImportJob{ID: "job-1", Status: domain.JobQueued, FilePaths: []string{"/csv/a.csv"}}
+		u.hub.register(job.ID)
+
+		events, unsubscribe, err := u.Subscribe(job.ID)
+		assert.NoError(t, err)
+		defer unsubscribe()
+
+		mockRepo.On("Update", job).Return(nil).Twice()
+		mockProcessor.On("ProcessCSVFiles", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				progressChan := args.Get(3).(chan<- *domain.ProgressUpdate)
+				progressChan <- &domain.ProgressUpdate{FileName: "/csv/a.csv", Percentage: 100}
+			}).
+			Return(&domain.FinalResult{FileResults: domain.FileResultMap{"/csv/a.csv": {}}}, nil)
+		mockLogger.On("Info", mock.Anything, mock.Anything).Return()
+
+		u.run(job)
+
+		var received []*domain.JobEvent
+		for event := range events {
+			received = append(received, event)
+		}
+
+		assert.Len(t, received, 2)
+		assert.Equal(t, domain.JobEventProgress, received[0].Type)
+		assert.Equal(t, 100.0, received[0].Progress.Percentage)
+		assert.Equal(t, domain.JobEventSummary, received[1].Type)
+		assert.Equal(t, domain.JobSucceeded, received[1].Job.Status)
+	})
+
+	t.Run("success - finished job yields summary", func(t *testing.T) {
+		mockRepo := domain.NewMockImportJobRepository(t)
+		u := &importJobUsecase{repo: mockRepo, hub: newProgressHub()}
+
+		mockRepo.On("FindByID", "job-2").Return(&domain.ImportJob{ID: "job-2", Status: domain.JobFailed}, nil)
+
+		events, unsubscribe, err := u.Subscribe("job-2")
+		assert.NoError(t, err)
+		defer unsubscribe()
+
+		event := <-events
+		assert.Equal(t, domain.JobEventSummary, event.Type)
+		assert.Equal(t, domain.JobFailed, event.Job.Status)
+
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+
+	t.Run("error - job not found", func(t *testing.T) {
+		mockRepo := domain.NewMockImportJobRepository(t)
+		u := &importJobUsecase{repo: mockRepo, hub: newProgressHub()}
+
+		mockRepo.On("FindByID", "missing").Return(nil, nil)
+
+		_, _, err := u.Subscribe("missing")
+
+		assert.ErrorIs(t, err, domain.ErrJobNotFound)
+	})
+}
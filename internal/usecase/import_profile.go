@@ -0,0 +1,233 @@
+// ============================================
+// internal/usecase/import_profile.go
+// ============================================
+package usecase
+
+import (
+	"data-processing/internal/domain"
+	"data-processing/pkg/availability"
+	"data-processing/pkg/csv"
+	"data-processing/pkg/gtin"
+	"data-processing/pkg/validator"
+	"fmt"
+	"strings"
+)
+
+type importProfileUsecase struct {
+	repo domain.ImportProfileRepository
+}
+
+func NewImportProfileUsecase(repo domain.ImportProfileRepository) domain.ImportProfileUsecase {
+	return &importProfileUsecase{repo: repo}
+}
+
+func (u *importProfileUsecase) SaveProfile(profile *domain.ImportProfile) error {
+	profile.Name = strings.TrimSpace(profile.Name)
+	if profile.Name == "" {
+		return fmt.Errorf("%w: name is required", domain.ErrInvalidProfile)
+	}
+
+	if _, err := profileOptions(profile); err != nil {
+		return err
+	}
+	if _, err := profileRules(profile); err != nil {
+		return err
+	}
+	if _, err := profileEanPolicy(profile); err != nil {
+		return err
+	}
+	if _, _, err := profileAvailability(profile); err != nil {
+		return err
+	}
+
+	return u.repo.Save(profile)
+}
+
+func (u *importProfileUsecase) GetProfile(name string) (*domain.ImportProfile, error) {
+	profile, err := u.repo.FindByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrProfileNotFound, name)
+	}
+	return profile, nil
+}
+
+func (u *importProfileUsecase) ListProfiles() ([]*domain.ImportProfile, error) {
+	return u.repo.GetAll()
+}
+
+// profileOptions converts an import profile into CSV reader options.
+func profileOptions(profile *domain.ImportProfile) (csv.Options, error) {
+	options := csv.Options{
+		Aliases:          make(map[string]csv.Column, len(profile.ColumnMapping)),
+		LazyQuotes:       profile.LazyQuotes,
+		Encoding:         profile.Encoding,
+		DecimalSeparator: profile.DecimalSeparator,
+		Transforms:       make(map[csv.Column][]string, len(profile.Transforms)),
+		Sheet:            profile.Sheet,
+		HeaderRow:        profile.HeaderRow,
+	}
+
+	if err := parseChars(&options, profile.Delimiter, profile.Quote, profile.Comment); err != nil {
+		return csv.Options{}, fmt.Errorf("%w: %v", domain.ErrInvalidProfile, err)
+	}
+
+	for header, name := range profile.ColumnMapping {
+		column, err := csv.ParseColumn(name)
+		if err != nil {
+			return csv.Options{}, fmt.Errorf("%w: column mapping %q: %v", domain.ErrInvalidProfile, header, err)
+		}
+		options.Aliases[header] = column
+	}
+
+	for name, transforms := range profile.Transforms {
+		column, err := csv.ParseColumn(name)
+		if err != nil {
+			return csv.Options{}, fmt.Errorf("%w: transforms: %v", domain.ErrInvalidProfile, err)
+		}
+		options.Transforms[column] = transforms
+	}
+
+	if err := options.Validate(); err != nil {
+		return csv.Options{}, fmt.Errorf("%w: %v", domain.ErrInvalidProfile, err)
+	}
+
+	return options, nil
+}
+
+// importSettings is what an import resolves from its profile and request.
+type importSettings struct {
+	csv   csv.Options
+	rules *validator.Validator
+	ean   gtin.Policy
+	// availability maps availability synonyms, stock checks availability
+	availability *availability.Vocabulary
+	stock        availability.StockRule
+	// convertCurrency is set by the profile, prices by the processor once
+	// it has loaded the exchange rates
+	convertCurrency bool
+	prices          *priceConversion
+	errorPolicy     domain.ErrorPolicy
+	atomic          domain.AtomicScope
+}
+
+// importOptions resolves the settings of an import: those of the named
+// profile, or the defaults, with the dialect overrides, error policy and
+// atomic scope of the request applied.
+func importOptions(profiles domain.ImportProfileRepository, options domain.ImportOptions) (importSettings, error) {
+	settings := importSettings{rules: validator.Default(), availability: availability.DefaultVocabulary()}
+	if options.Profile != "" {
+		profile, err := profiles.FindByName(options.Profile)
+		if err != nil {
+			return importSettings{}, err
+		}
+		if profile == nil {
+			return importSettings{}, fmt.Errorf("%w: %s", domain.ErrProfileNotFound, options.Profile)
+		}
+
+		if settings.csv, err = profileOptions(profile); err != nil {
+			return importSettings{}, err
+		}
+		if settings.rules, err = profileRules(profile); err != nil {
+			return importSettings{}, err
+		}
+		if settings.ean, err = profileEanPolicy(profile); err != nil {
+			return importSettings{}, err
+		}
+		if settings.availability, settings.stock, err = profileAvailability(profile); err != nil {
+			return importSettings{}, err
+		}
+		settings.convertCurrency = profile.ConvertCurrency
+	}
+
+	var err error
+	if settings.csv, err = applyDialect(settings.csv, options.Dialect); err != nil {
+		return importSettings{}, err
+	}
+	if err := validateErrorPolicy(options.ErrorPolicy); err != nil {
+		return importSettings{}, err
+	}
+	settings.errorPolicy = options.ErrorPolicy
+	if !options.Atomic.Valid() {
+		return importSettings{}, fmt.Errorf("%w: %q, want file or request", domain.ErrInvalidAtomicScope, options.Atomic)
+	}
+	settings.atomic = options.Atomic
+	return settings, nil
+}
+
+// profileRules compiles the validation rules of an import profile.
+func profileRules(profile *domain.ImportProfile) (*validator.Validator, error) {
+	rules, err := validator.New(profile.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("%w: rules: %v", domain.ErrInvalidProfile, err)
+	}
+	return rules, nil
+}
+
+// profileEanPolicy parses how an import profile checks EANs.
+func profileEanPolicy(profile *domain.ImportProfile) (gtin.Policy, error) {
+	policy, err := gtin.ParsePolicy(profile.EanStrictness, profile.EanFormat)
+	if err != nil {
+		return gtin.Policy{}, fmt.Errorf("%w: ean: %v", domain.ErrInvalidProfile, err)
+	}
+	return policy, nil
+}
+
+// profileAvailability builds the availability synonyms of an import
+// profile and parses how availability is checked against stock.
+func profileAvailability(profile *domain.ImportProfile) (*availability.Vocabulary, availability.StockRule, error) {
+	vocabulary, err := availability.NewVocabulary(profile.AvailabilitySynonyms)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: availability: %v", domain.ErrInvalidProfile, err)
+	}
+	rule, err := availability.ParseStockRule(profile.AvailabilityStock)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: availability: %v", domain.ErrInvalidProfile, err)
+	}
+	return vocabulary, rule, nil
+}
+
+// applyDialect overrides the parsing settings in options with the ones set
+// in dialect.
+func applyDialect(options csv.Options, dialect domain.CSVDialect) (csv.Options, error) {
+	if err := parseChars(&options, dialect.Delimiter, dialect.Quote, dialect.Comment); err != nil {
+		return csv.Options{}, fmt.Errorf("%w: %v", domain.ErrInvalidDialect, err)
+	}
+	if dialect.LazyQuotes != nil {
+		options.LazyQuotes = *dialect.LazyQuotes
+	}
+	if dialect.Encoding != "" {
+		options.Encoding = dialect.Encoding
+	}
+
+	if err := options.Validate(); err != nil {
+		return csv.Options{}, fmt.Errorf("%w: %v", domain.ErrInvalidDialect, err)
+	}
+	return options, nil
+}
+
+// parseChars sets the delimiter, quote and comment prefix of options from
+// the non-empty values.
+func parseChars(options *csv.Options, delimiter, quote, comment string) error {
+	for _, field := range []struct {
+		name  string
+		value string
+		dest  *rune
+	}{
+		{"delimiter", delimiter, &options.Delimiter},
+		{"quote", quote, &options.Quote},
+		{"comment", comment, &options.Comment},
+	} {
+		if field.value == "" {
+			continue
+		}
+		r, err := csv.ParseChar(field.value)
+		if err != nil {
+			return fmt.Errorf("%s: %v", field.name, err)
+		}
+		*field.dest = r
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+// ============================================
+// internal/usecase/job_control.go
+// ============================================
+package usecase
+
+import (
+	"context"
+	"data-processing/internal/domain"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// runControl implements domain.JobControl. Workers stop being fed while it
+// is paused, and cancelling it cancels the context of the run.
+type runControl struct {
+	mu        sync.Mutex
+	cond      *sync.Cond
+	paused    bool
+	cancelled bool
+	cancelRun context.CancelCauseFunc
+}
+
+func newRunControl() *runControl {
+	c := &runControl{}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *runControl) Checkpoint(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.paused && !c.cancelled {
+		// Wake the wait below if the context ends while paused
+		stop := context.AfterFunc(ctx, func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			c.cond.Broadcast()
+		})
+		defer stop()
+
+		for c.paused && !c.cancelled && ctx.Err() == nil {
+			c.cond.Wait()
+		}
+	}
+	if c.cancelled {
+		return domain.ErrJobCancelled
+	}
+	return cancellation(ctx)
+}
+
+// bind attaches the cancel function of the run context, cancelling it
+// straight away if the job was cancelled while queued.
+func (c *runControl) bind(cancelRun context.CancelCauseFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cancelRun = cancelRun
+	if c.cancelled {
+		cancelRun(domain.ErrJobCancelled)
+	}
+}
+
+func (c *runControl) pause() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.paused = true
+}
+
+func (c *runControl) resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.paused = false
+	c.cond.Broadcast()
+}
+
+func (c *runControl) cancel() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cancelled = true
+	if c.cancelRun != nil {
+		c.cancelRun(domain.ErrJobCancelled)
+	}
+	c.cond.Broadcast()
+}
+
+// checkpoint only checks ctx when the run has no control attached.
+func checkpoint(ctx context.Context, control domain.JobControl) error {
+	if control == nil {
+		return cancellation(ctx)
+	}
+	return control.Checkpoint(ctx)
+}
+
+// cancellation returns nil while ctx is live, otherwise an error wrapping
+// domain.ErrJobCancelled that carries the cause, e.g. a deadline.
+func cancellation(ctx context.Context) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+
+	cause := context.Cause(ctx)
+	if errors.Is(cause, domain.ErrJobCancelled) {
+		return cause
+	}
+	return fmt.Errorf("%w: %v", domain.ErrJobCancelled, cause)
+}
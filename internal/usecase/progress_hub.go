@@ -0,0 +1,100 @@
+// ============================================
+// internal/usecase/progress_hub.go
+// ============================================
+package usecase
+
+import (
+	"data-processing/internal/domain"
+	"sync"
+)
+
+// subscriberBuffer is how many events a slow subscriber may fall behind
+// before progress updates are dropped for it.
+const subscriberBuffer = 64
+
+// progressHub fans out job events to every subscriber of a job. Publishing
+// never blocks, so a slow client cannot stall the import.
+type progressHub struct {
+	mu   sync.Mutex
+	jobs map[string]map[chan *domain.JobEvent]struct{}
+}
+
+func newProgressHub() *progressHub {
+	return &progressHub{
+		jobs: make(map[string]map[chan *domain.JobEvent]struct{}),
+	}
+}
+
+// register marks a job as active so clients can subscribe to it.
+func (h *progressHub) register(jobID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.jobs[jobID]; !ok {
+		h.jobs[jobID] = make(map[chan *domain.JobEvent]struct{})
+	}
+}
+
+// subscribe returns a channel of events for an active job, or false when
+// the job is not running in this process.
+func (h *progressHub) subscribe(jobID string) (<-chan *domain.JobEvent, func(), bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subscribers, ok := h.jobs[jobID]
+	if !ok {
+		return nil, nil, false
+	}
+
+	ch := make(chan *domain.JobEvent, subscriberBuffer)
+	subscribers[ch] = struct{}{}
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		if _, ok := h.jobs[jobID][ch]; ok {
+			delete(h.jobs[jobID], ch)
+			close(ch)
+		}
+	}
+	return ch, unsubscribe, true
+}
+
+// publish sends event to every subscriber, dropping it for subscribers
+// whose buffer is full.
+func (h *progressHub) publish(jobID string, event *domain.JobEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.jobs[jobID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// finish delivers the final event to every subscriber, making room for it
+// if needed, then closes their channels and forgets the job.
+func (h *progressHub) finish(jobID string, event *domain.JobEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.jobs[jobID] {
+		select {
+		case ch <- event:
+		default:
+			// Drop the oldest event, unless the subscriber drained it in
+			// the meantime. Events are only sent under h.mu, so the send
+			// below cannot block
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- event
+		}
+		close(ch)
+	}
+	delete(h.jobs, jobID)
+}
@@ -4,14 +4,27 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"data-processing/config"
 	handler "data-processing/internal/delivery/http"
+	"data-processing/internal/delivery/watcher"
+	"data-processing/internal/domain"
 	"data-processing/internal/repository"
 	"data-processing/internal/usecase"
+	"data-processing/pkg/csv"
+	"data-processing/pkg/currency"
 	"data-processing/pkg/database"
 	"data-processing/pkg/logger"
+	"data-processing/pkg/sandbox"
+	"data-processing/pkg/upload"
 
 	"github.com/gin-gonic/gin"
 )
@@ -29,15 +42,91 @@ func main() {
 		log.Fatalf("Failed to connect database: %v", err)
 	}
 
-	repo := repository.NewGormRepository(db)
-	uc := usecase.NewCSVProcessorUsecase(repo, appLogger, cfg.WorkerCount, cfg.BatchSize)
-	handler := handler.NewHandler(uc)
+	aliases, err := csv.ParseAliases(cfg.ColumnAliases)
+	if err != nil {
+		log.Fatalf("Invalid COLUMN_ALIASES: %v", err)
+	}
+
+	var repo domain.ProductRepository
+	switch cfg.UpsertStrategy {
+	case "gorm":
+		repo = repository.NewGormRepository(db)
+	case "copy":
+		repo = repository.NewCopyRepository(db)
+	default:
+		log.Fatalf("Invalid UPSERT_STRATEGY %q, expected gorm or copy", cfg.UpsertStrategy)
+	}
+	profileRepo := repository.NewImportProfileRepository(db)
+	rateRepo := repository.NewExchangeRateRepository(db)
+	jobRepo := repository.NewImportJobRepository(db)
+	importRoot, err := sandbox.NewRoot(cfg.ImportDir)
+	if err != nil {
+		log.Fatalf("Invalid IMPORT_DIR: %v", err)
+	}
+
+	baseCurrency := currency.Normalize(cfg.BaseCurrency)
+	if baseCurrency != "" && !currency.Valid(baseCurrency) {
+		log.Fatalf("Invalid BASE_CURRENCY %q, expected an ISO 4217 currency code", cfg.BaseCurrency)
+	}
+
+	csvReader := csv.NewReader(aliases)
+	uc := usecase.NewCSVProcessorUsecase(repo, profileRepo, rateRepo, csvReader, importRoot, appLogger, cfg.WorkerCount, cfg.BatchSize, baseCurrency)
+	profileUc := usecase.NewImportProfileUsecase(profileRepo)
+	rateUc := usecase.NewExchangeRateUsecase(rateRepo)
+	jobUc := usecase.NewImportJobUsecase(jobRepo, profileRepo, uc, importRoot, appLogger, cfg.JobQueueSize, cfg.JobRunners, cfg.JobTimeout)
+	fileStore, err := upload.NewStore(importRoot.Dir(), cfg.UploadDir, cfg.UploadMaxSize)
+	if err != nil {
+		log.Fatalf("Invalid UPLOAD_DIR: %v", err)
+	}
+	handler := handler.NewHandler(jobUc, profileUc, rateUc, fileStore, cfg.UploadMaxFiles, cfg.UploadMaxRequestSize, cfg.WSAllowedOrigins)
 
 	r := gin.Default()
 	handler.RegisterRoutes(r)
 
-	log.Printf("Server starting on port %s with %d workers", cfg.ServerPort, cfg.WorkerCount)
-	if err := r.Run(":" + cfg.ServerPort); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	srv := &http.Server{
+		Addr:    ":" + cfg.ServerPort,
+		Handler: r,
+	}
+
+	go func() {
+		log.Printf("Server starting on port %s with %d workers", cfg.ServerPort, cfg.WorkerCount)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	watchDone := make(chan struct{})
+	if cfg.WatchDir != "" {
+		inbox, err := watcher.NewWatcher(jobUc, appLogger, importRoot.Dir(), cfg.WatchDir, cfg.WatchProfile, cfg.WatchSettle)
+		if err != nil {
+			log.Fatalf("Invalid WATCH_DIR: %v", err)
+		}
+		go func() {
+			defer close(watchDone)
+			if err := inbox.Run(ctx); err != nil {
+				log.Printf("Watch folder stopped: %v", err)
+			}
+		}()
+	} else {
+		close(watchDone)
+	}
+
+	<-ctx.Done()
+
+	// Stop taking requests, then cancel running imports so their committed
+	// rows are recorded before exiting
+	log.Println("Shutting down server...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Server shutdown: %v", err)
+	}
+	if err := jobUc.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Import jobs shutdown: %v", err)
 	}
+	<-watchDone
 }
@@ -0,0 +1,5 @@
+BEGIN;
+
+drop table if exists import_profiles;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,14 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS import_profiles (
+    name VARCHAR(100) PRIMARY KEY NOT NULL,
+    column_mapping JSONB NOT NULL DEFAULT '{}',
+    delimiter VARCHAR(4) NULL,
+    encoding VARCHAR(50) NULL,
+    decimal_separator VARCHAR(4) NULL,
+    transforms JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NULL
+);
+
+COMMIT;
\ No newline at end of file
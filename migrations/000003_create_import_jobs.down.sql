@@ -0,0 +1,5 @@
+BEGIN;
+
+drop table if exists import_jobs;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,23 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id UUID PRIMARY KEY NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    file_paths JSONB NOT NULL DEFAULT '[]',
+    profile VARCHAR(100) NULL,
+    total_records int DEFAULT 0,
+    inserted int DEFAULT 0,
+    updated int DEFAULT 0,
+    failed int DEFAULT 0,
+    errors JSONB NULL,
+    file_results JSONB NULL,
+    error TEXT NULL,
+    processing_time BIGINT DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ NULL,
+    finished_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_jobs_status_created_at ON import_jobs (status, created_at DESC);
+
+COMMIT;
\ No newline at end of file
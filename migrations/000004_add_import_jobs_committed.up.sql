@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE import_jobs ADD COLUMN IF NOT EXISTS committed int DEFAULT 0;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE import_profiles DROP COLUMN IF EXISTS header_row;
+ALTER TABLE import_profiles DROP COLUMN IF EXISTS sheet;
+
+COMMIT;
\ No newline at end of file
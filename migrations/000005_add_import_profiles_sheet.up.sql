@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE import_profiles ADD COLUMN IF NOT EXISTS sheet VARCHAR(100) NULL;
+ALTER TABLE import_profiles ADD COLUMN IF NOT EXISTS header_row int NOT NULL DEFAULT 0;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE import_jobs DROP COLUMN IF EXISTS dialect;
+
+ALTER TABLE import_profiles DROP COLUMN IF EXISTS lazy_quotes;
+ALTER TABLE import_profiles DROP COLUMN IF EXISTS comment;
+ALTER TABLE import_profiles DROP COLUMN IF EXISTS quote;
+
+COMMIT;
\ No newline at end of file
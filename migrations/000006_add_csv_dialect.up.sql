@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE import_profiles ADD COLUMN IF NOT EXISTS quote VARCHAR(4) NULL;
+ALTER TABLE import_profiles ADD COLUMN IF NOT EXISTS comment VARCHAR(4) NULL;
+ALTER TABLE import_profiles ADD COLUMN IF NOT EXISTS lazy_quotes boolean NOT NULL DEFAULT false;
+
+ALTER TABLE import_jobs ADD COLUMN IF NOT EXISTS dialect JSONB NOT NULL DEFAULT '{}';
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE import_profiles DROP COLUMN IF EXISTS rules;
+
+COMMIT;
\ No newline at end of file
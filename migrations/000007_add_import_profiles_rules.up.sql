@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE import_profiles ADD COLUMN IF NOT EXISTS rules JSONB NOT NULL DEFAULT '{}';
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE import_profiles DROP COLUMN IF EXISTS ean_format;
+ALTER TABLE import_profiles DROP COLUMN IF EXISTS ean_strictness;
+
+COMMIT;
\ No newline at end of file
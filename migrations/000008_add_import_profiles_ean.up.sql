@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE import_profiles ADD COLUMN IF NOT EXISTS ean_strictness VARCHAR(10) NULL;
+ALTER TABLE import_profiles ADD COLUMN IF NOT EXISTS ean_format VARCHAR(10) NULL;
+
+COMMIT;
\ No newline at end of file
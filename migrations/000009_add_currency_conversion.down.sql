@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE import_profiles DROP COLUMN IF EXISTS convert_currency;
+
+ALTER TABLE products DROP COLUMN IF EXISTS original_currency;
+ALTER TABLE products DROP COLUMN IF EXISTS original_price;
+
+DROP TABLE IF EXISTS exchange_rates;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    currency VARCHAR(3) NOT NULL,
+    base_currency VARCHAR(3) NOT NULL,
+    rate DECIMAL(18, 8) NOT NULL,
+    updated_at TIMESTAMPTZ NULL,
+    PRIMARY KEY (currency, base_currency)
+);
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS original_price DECIMAL(10, 2) NULL;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS original_currency VARCHAR(3) NULL;
+
+ALTER TABLE import_profiles ADD COLUMN IF NOT EXISTS convert_currency boolean NOT NULL DEFAULT false;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE import_profiles DROP COLUMN IF EXISTS availability_stock;
+ALTER TABLE import_profiles DROP COLUMN IF EXISTS availability_synonyms;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE import_profiles ADD COLUMN IF NOT EXISTS availability_synonyms JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE import_profiles ADD COLUMN IF NOT EXISTS availability_stock VARCHAR(10) NULL;
+
+COMMIT;
\ No newline at end of file
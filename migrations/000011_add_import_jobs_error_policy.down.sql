@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE import_jobs DROP COLUMN IF EXISTS error_policy;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE import_jobs ADD COLUMN IF NOT EXISTS atomic VARCHAR(10) NOT NULL DEFAULT '';
+
+COMMIT;
\ No newline at end of file
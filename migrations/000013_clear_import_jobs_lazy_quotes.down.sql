@@ -0,0 +1,5 @@
+BEGIN;
+
+UPDATE import_jobs SET dialect = jsonb_set(dialect, '{LazyQuotes}', 'false') WHERE COALESCE(dialect->'LazyQuotes', 'null') = 'null';
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,5 @@
+BEGIN;
+
+UPDATE import_jobs SET dialect = dialect - 'LazyQuotes' WHERE dialect->'LazyQuotes' = 'false';
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,144 @@
+// ============================================
+// pkg/availability/availability.go
+// ============================================
+package availability
+
+import (
+	"data-processing/internal/domain"
+	"fmt"
+	"strings"
+)
+
+// StockRule says how availability is checked against stock.
+type StockRule string
+
+const (
+	// StockOff leaves availability as imported
+	StockOff StockRule = "off"
+	// StockDerive fills an empty availability from stock
+	StockDerive StockRule = "derive"
+	// StockFlag also reports availabilities that contradict stock
+	StockFlag StockRule = "flag"
+	// StockReject also fails rows whose availability contradicts stock
+	StockReject StockRule = "reject"
+)
+
+// ParseStockRule parses a stock rule, which defaults to off.
+func ParseStockRule(rule string) (StockRule, error) {
+	switch r := StockRule(strings.ToLower(rule)); r {
+	case "":
+		return StockOff, nil
+	case StockOff, StockDerive, StockFlag, StockReject:
+		return r, nil
+	default:
+		return "", fmt.Errorf("unknown stock rule %q", rule)
+	}
+}
+
+// defaultSynonyms holds the spellings found in supplier feeds. Every
+// availability is also its own synonym.
+var defaultSynonyms = map[string]domain.Availability{
+	"available":      domain.AvailabilityInStock,
+	"in stock":       domain.AvailabilityInStock,
+	"limited":        domain.AvailabilityLimitedStock,
+	"low stock":      domain.AvailabilityLimitedStock,
+	"few left":       domain.AvailabilityLimitedStock,
+	"out of stock":   domain.AvailabilityOutOfStock,
+	"sold out":       domain.AvailabilityOutOfStock,
+	"unavailable":    domain.AvailabilityOutOfStock,
+	"not available":  domain.AvailabilityOutOfStock,
+	"back order":     domain.AvailabilityBackorder,
+	"backordered":    domain.AvailabilityBackorder,
+	"on backorder":   domain.AvailabilityBackorder,
+	"pre order":      domain.AvailabilityPreOrder,
+	"coming soon":    domain.AvailabilityPreOrder,
+	"discontinued":   domain.AvailabilityDiscontinued,
+	"no longer sold": domain.AvailabilityDiscontinued,
+}
+
+// Vocabulary maps availability values and their synonyms to
+// availabilities.
+type Vocabulary struct {
+	synonyms map[string]domain.Availability
+}
+
+var defaultVocabulary = mustNewVocabulary(nil)
+
+// DefaultVocabulary returns the vocabulary of the built-in synonyms.
+func DefaultVocabulary() *Vocabulary {
+	return defaultVocabulary
+}
+
+func mustNewVocabulary(synonyms map[string]string) *Vocabulary {
+	v, err := NewVocabulary(synonyms)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// NewVocabulary creates a vocabulary for the built-in synonyms and
+// synonyms, which map a value to the name of an availability and take
+// precedence.
+func NewVocabulary(synonyms map[string]string) (*Vocabulary, error) {
+	v := &Vocabulary{synonyms: make(map[string]domain.Availability, len(defaultSynonyms)+len(domain.Availabilities)+len(synonyms))}
+	for _, availability := range domain.Availabilities {
+		v.synonyms[key(string(availability))] = availability
+	}
+	for synonym, availability := range defaultSynonyms {
+		v.synonyms[key(synonym)] = availability
+	}
+
+	for synonym, name := range synonyms {
+		availability := domain.Availability(name)
+		if !availability.Valid() {
+			return nil, fmt.Errorf("synonym %q: unknown availability %q", synonym, name)
+		}
+		if key(synonym) == "" {
+			return nil, fmt.Errorf("empty synonym for %q", name)
+		}
+		v.synonyms[key(synonym)] = availability
+	}
+	return v, nil
+}
+
+// Normalize returns the availability value stands for, or value unchanged
+// when it is empty or unknown. Case, spaces, hyphens and underscores are
+// ignored, so "In Stock" and "in-stock" are both in_stock. A nil
+// vocabulary has the built-in synonyms.
+func (v *Vocabulary) Normalize(value string) string {
+	if v == nil {
+		v = defaultVocabulary
+	}
+	if availability, ok := v.synonyms[key(value)]; ok {
+		return string(availability)
+	}
+	return value
+}
+
+var separators = strings.NewReplacer(" ", "", "-", "", "_", "")
+
+func key(value string) string {
+	return separators.Replace(strings.ToLower(value))
+}
+
+// FromStock returns the availability implied by stock.
+func FromStock(stock int) domain.Availability {
+	if stock > 0 {
+		return domain.AvailabilityInStock
+	}
+	return domain.AvailabilityOutOfStock
+}
+
+// Contradicts reports whether availability cannot be true with stock:
+// in or limited stock with none left, or out of stock with some.
+func Contradicts(availability domain.Availability, stock int) bool {
+	switch availability {
+	case domain.AvailabilityInStock, domain.AvailabilityLimitedStock:
+		return stock <= 0
+	case domain.AvailabilityOutOfStock:
+		return stock > 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,84 @@
+// ============================================
+// pkg/availability/availability_test.go
+// ============================================
+package availability
+
+import (
+	"data-processing/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVocabulary_Normalize(t *testing.T) {
+	t.Run("success - built-in synonyms", func(t *testing.T) {
+		v := DefaultVocabulary()
+
+		for value, want := range map[string]string{
+			"in_stock":     "in_stock",
+			"In Stock":     "in_stock",
+			"instock":      "in_stock",
+			"available":    "in_stock",
+			"Out-of-Stock": "out_of_stock",
+			"Sold out":     "out_of_stock",
+			"pre-order":    "pre_order",
+			" BACKORDER ":  "backorder",
+		} {
+			assert.Equal(t, want, v.Normalize(value), value)
+		}
+	})
+
+	t.Run("success - unknown and empty values kept", func(t *testing.T) {
+		v := DefaultVocabulary()
+
+		assert.Equal(t, "maybe", v.Normalize("maybe"))
+		assert.Equal(t, "", v.Normalize(""))
+	})
+
+	t.Run("success - profile synonyms take precedence", func(t *testing.T) {
+		v, err := NewVocabulary(map[string]string{
+			"Lieferbar": "in_stock",
+			"limited":   "backorder",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "in_stock", v.Normalize("lieferbar"))
+		assert.Equal(t, "backorder", v.Normalize("Limited"))
+		assert.Equal(t, "out_of_stock", v.Normalize("sold out"))
+	})
+
+	t.Run("error - unknown availability", func(t *testing.T) {
+		v, err := NewVocabulary(map[string]string{"Lieferbar": "yes"})
+
+		assert.Error(t, err)
+		assert.Nil(t, v)
+	})
+}
+
+func TestParseStockRule(t *testing.T) {
+	rule, err := ParseStockRule("")
+	assert.NoError(t, err)
+	assert.Equal(t, StockOff, rule)
+
+	rule, err = ParseStockRule("Reject")
+	assert.NoError(t, err)
+	assert.Equal(t, StockReject, rule)
+
+	_, err = ParseStockRule("strict")
+	assert.Error(t, err)
+}
+
+func TestContradicts(t *testing.T) {
+	assert.True(t, Contradicts(domain.AvailabilityInStock, 0))
+	assert.True(t, Contradicts(domain.AvailabilityLimitedStock, -1))
+	assert.True(t, Contradicts(domain.AvailabilityOutOfStock, 3))
+	assert.False(t, Contradicts(domain.AvailabilityInStock, 3))
+	assert.False(t, Contradicts(domain.AvailabilityPreOrder, 0))
+	assert.False(t, Contradicts("", 0))
+}
+
+func TestFromStock(t *testing.T) {
+	assert.Equal(t, domain.AvailabilityInStock, FromStock(1))
+	assert.Equal(t, domain.AvailabilityOutOfStock, FromStock(0))
+}
@@ -0,0 +1,126 @@
+// ============================================
+// pkg/csv/archive.go
+// ============================================
+package csv
+
+import (
+	"archive/zip"
+	"data-processing/internal/domain"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+// ArchiveSeparator joins the path of a zip archive and the name of an
+// entry inside it, as in "archive.zip!/inner.csv".
+const ArchiveSeparator = "!/"
+
+// ArchiveEntries lists the CSV and JSON files, plain or gzip and zstd
+// compressed, inside the zip archive at filePath in archive order. It returns nil when the file is not a zip archive.
+func ArchiveEntries(filePath string) ([]string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	magic := make([]byte, len(zipMagic))
+	n, err := io.ReadFull(file, magic)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, err
+	}
+	if !isZipMagic(magic[:n]) {
+		return nil, nil
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	// A workbook is a zip archive read as a single file
+	if isWorkbook(file, info.Size()) {
+		return nil, nil
+	}
+
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	var entries []string
+	for _, entry := range archive.File {
+		if isRecordEntry(entry) {
+			entries = append(entries, entry.Name)
+		}
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("archive contains no CSV or JSON files")
+	}
+	return entries, nil
+}
+
+// isRecordEntry accepts CSV and JSON files such as data.csv or
+// rows.ndjson.zst, skipping directories, hidden files and macOS resource
+// forks.
+func isRecordEntry(entry *zip.File) bool {
+	if entry.FileInfo().IsDir() || strings.HasPrefix(entry.Name, "__MACOSX/") {
+		return false
+	}
+	name := path.Base(entry.Name)
+	if strings.HasPrefix(name, ".") {
+		return false
+	}
+
+	switch DetectFormat(name) {
+	case FormatCSV, FormatJSON, FormatNDJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// OpenEntry opens the CSV or JSON file named entry inside the zip archive
+// at filePath. Gzip and zstd entries are decompressed on the fly. Progress
+// is measured against the uncompressed entry size, which for those entries
+// is their gzip or zstd compressed size.
+func (r *Reader) OpenEntry(filePath, entry string, opts Options) (domain.RecordSource, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var file *zip.File
+	for _, candidate := range archive.File {
+		if candidate.Name == entry {
+			file = candidate
+			break
+		}
+	}
+	if file == nil {
+		archive.Close()
+		return nil, fmt.Errorf("archive entry %s not found", entry)
+	}
+
+	contents, err := file.Open()
+	if err != nil {
+		archive.Close()
+		return nil, err
+	}
+
+	counter := &countingReader{r: contents}
+	input, err := decompress(counter)
+	if err != nil {
+		contents.Close()
+		archive.Close()
+		return nil, err
+	}
+
+	return r.openText(DetectFormat(entry), input, counter, int64(file.UncompressedSize64), opts, input, contents, archive)
+}
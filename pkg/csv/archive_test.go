@@ -0,0 +1,133 @@
+// ============================================
+// pkg/csv/archive_test.go
+// ============================================
+package csv
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestZip writes a zip archive holding files, in name order.
+func writeTestZip(t *testing.T, files map[string]string) string {
+	filePath := filepath.Join(t.TempDir(), "products.zip")
+	file, err := os.Create(filePath)
+	require.NoError(t, err)
+	defer file.Close()
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	archive := zip.NewWriter(file)
+	for _, name := range names {
+		entry, err := archive.Create(name)
+		require.NoError(t, err)
+		_, err = entry.Write([]byte(files[name]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	return filePath
+}
+
+func TestArchiveEntries(t *testing.T) {
+	t.Run("success - lists CSV entries", func(t *testing.T) {
+		filePath := writeTestZip(t, map[string]string{
+			"a.csv":              testHeader,
+			"nested/B.CSV":       testHeader,
+			"feed.ndjson":        `{"id": 1}`,
+			"data.csv.gz":        "gzip",
+			"rows.ndjson.zst":    "zstd",
+			"backup.gz":          "unknown format",
+			"products.xlsx":      "not read from archives",
+			"nested/":            "",
+			"readme.txt":         "not a csv",
+			".hidden.csv":        testHeader,
+			"__MACOSX/._a.csv":   "resource fork",
+			"nested/.hidden.csv": testHeader,
+		})
+
+		entries, err := ArchiveEntries(filePath)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a.csv", "data.csv.gz", "feed.ndjson", "nested/B.CSV", "rows.ndjson.zst"}, entries)
+	})
+
+	t.Run("success - not an archive", func(t *testing.T) {
+		entries, err := ArchiveEntries(writeTestCSV(t, testHeader))
+
+		assert.NoError(t, err)
+		assert.Nil(t, entries)
+	})
+
+	t.Run("error - no CSV entries", func(t *testing.T) {
+		entries, err := ArchiveEntries(writeTestZip(t, map[string]string{"readme.txt": "hello"}))
+
+		assert.Error(t, err)
+		assert.Nil(t, entries)
+	})
+}
+
+func TestReader_OpenEntry(t *testing.T) {
+	t.Run("success - streams entry", func(t *testing.T) {
+		content := testHeader + "1,Fan,Desc,Brand,Category,10,USD,5,123,Red,M,in_stock,7\n"
+		filePath := writeTestZip(t, map[string]string{
+			"a.csv": testHeader,
+			"b.csv": content,
+		})
+
+		stream, err := NewReader(nil).OpenEntry(filePath, "b.csv", Options{})
+		require.NoError(t, err)
+		defer stream.Close()
+
+		record, err := stream.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "Fan", record.Name)
+
+		_, err = stream.Next()
+		assert.Equal(t, io.EOF, err)
+		assert.Equal(t, int64(len(content)), stream.Size())
+		assert.Equal(t, stream.Size(), stream.Offset())
+	})
+
+	t.Run("success - decompresses entry", func(t *testing.T) {
+		var gzipped bytes.Buffer
+		gzipWriter := gzip.NewWriter(&gzipped)
+		_, err := gzipWriter.Write([]byte(testHeader + "1,Fan,Desc,Brand,Category,10,USD,5,123,Red,M,in_stock,7\n"))
+		require.NoError(t, err)
+		require.NoError(t, gzipWriter.Close())
+		filePath := writeTestZip(t, map[string]string{"data.csv.gz": gzipped.String()})
+
+		stream, err := NewReader(nil).OpenEntry(filePath, "data.csv.gz", Options{})
+		require.NoError(t, err)
+		defer stream.Close()
+
+		record, err := stream.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "Fan", record.Name)
+
+		_, err = stream.Next()
+		assert.Equal(t, io.EOF, err)
+		assert.Equal(t, int64(gzipped.Len()), stream.Size())
+	})
+
+	t.Run("error - entry not found", func(t *testing.T) {
+		filePath := writeTestZip(t, map[string]string{"a.csv": testHeader})
+
+		stream, err := NewReader(nil).OpenEntry(filePath, "b.csv", Options{})
+
+		assert.Error(t, err)
+		assert.Nil(t, stream)
+	})
+}
@@ -0,0 +1,171 @@
+// ============================================
+// pkg/csv/columns.go
+// ============================================
+package csv
+
+import (
+	"data-processing/internal/domain"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Column identifies the CSVRecord field a header maps to.
+type Column string
+
+const (
+	ColumnID           Column = "id"
+	ColumnName         Column = "name"
+	ColumnDescription  Column = "description"
+	ColumnBrand        Column = "brand"
+	ColumnCategory     Column = "category"
+	ColumnPrice        Column = "price"
+	ColumnCurrency     Column = "currency"
+	ColumnStock        Column = "stock"
+	ColumnEan          Column = "ean"
+	ColumnColor        Column = "color"
+	ColumnSize         Column = "size"
+	ColumnAvailability Column = "availability"
+	ColumnInternalId   Column = "internal_id"
+)
+
+// Columns lists every known column in record order.
+var Columns = []Column{
+	ColumnID, ColumnName, ColumnDescription, ColumnBrand, ColumnCategory,
+	ColumnPrice, ColumnCurrency, ColumnStock, ColumnEan, ColumnColor,
+	ColumnSize, ColumnAvailability, ColumnInternalId,
+}
+
+// RequiredColumns must be present in the header of every file.
+var RequiredColumns = []Column{
+	ColumnID, ColumnName, ColumnPrice, ColumnStock, ColumnInternalId,
+}
+
+// DefaultAliases maps normalised header names to columns. Every column name
+// is also accepted as its own alias.
+var DefaultAliases = map[string]Column{
+	"index":       ColumnID,
+	"product_id":  ColumnID,
+	"sku":         ColumnID,
+	"title":       ColumnName,
+	"product":     ColumnName,
+	"qty":         ColumnStock,
+	"quantity":    ColumnStock,
+	"gtin":        ColumnEan,
+	"internalid":  ColumnInternalId,
+	"internal_no": ColumnInternalId,
+}
+
+// ParseColumn returns the column with the given name.
+func ParseColumn(name string) (Column, error) {
+	column := Column(normalizeHeader(name))
+	if !isKnownColumn(column) {
+		return "", fmt.Errorf("unknown column %q", name)
+	}
+	return column, nil
+}
+
+// ParseAliases parses an alias table in the form
+// "Index:id,product_id:id,Internal ID:internal_id".
+func ParseAliases(value string) (map[string]Column, error) {
+	aliases := make(map[string]Column)
+	for _, entry := range strings.Split(value, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		header, column, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid column alias %q", entry)
+		}
+
+		col, err := ParseColumn(column)
+		if err != nil {
+			return nil, fmt.Errorf("invalid alias %q: %v", entry, err)
+		}
+		aliases[normalizeHeader(header)] = col
+	}
+	return aliases, nil
+}
+
+// columnMap holds the position of each column in a file.
+type columnMap map[Column]int
+
+// resolveColumns maps each header to a column using the alias table,
+// ignoring headers that are not recognised.
+func resolveColumns(header []string, aliases map[string]Column) (columnMap, error) {
+	columns := make(columnMap)
+	for i, name := range header {
+		key := normalizeHeader(name)
+
+		column, ok := aliases[key]
+		if !ok {
+			column = Column(key)
+			if !isKnownColumn(column) {
+				continue
+			}
+		}
+
+		if _, exists := columns[column]; exists {
+			return nil, fmt.Errorf("duplicate header for column %s: %q", column, name)
+		}
+		columns[column] = i
+	}
+
+	var missing []string
+	for _, column := range RequiredColumns {
+		if _, ok := columns[column]; !ok {
+			missing = append(missing, string(column))
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("missing required column(s): %s", strings.Join(missing, ", "))
+	}
+
+	return columns, nil
+}
+
+// value returns the field for column, or an empty string when the column is
+// absent from the file or the row is too short.
+func (m columnMap) value(record []string, column Column) string {
+	i, ok := m[column]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return record[i]
+}
+
+func (m columnMap) toRecord(record []string, rowNumber int) *domain.CSVRecord {
+	return &domain.CSVRecord{
+		ID:           m.value(record, ColumnID),
+		Name:         m.value(record, ColumnName),
+		Description:  m.value(record, ColumnDescription),
+		Brand:        m.value(record, ColumnBrand),
+		Category:     m.value(record, ColumnCategory),
+		Price:        m.value(record, ColumnPrice),
+		Currency:     m.value(record, ColumnCurrency),
+		Stock:        m.value(record, ColumnStock),
+		Ean:          m.value(record, ColumnEan),
+		Color:        m.value(record, ColumnColor),
+		Size:         m.value(record, ColumnSize),
+		Availability: m.value(record, ColumnAvailability),
+		InternalId:   m.value(record, ColumnInternalId),
+		RowNumber:    rowNumber,
+	}
+}
+
+func normalizeHeader(name string) string {
+	name = strings.TrimPrefix(name, "\ufeff")
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+}
+
+func isKnownColumn(column Column) bool {
+	for _, c := range Columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
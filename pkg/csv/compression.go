@@ -0,0 +1,55 @@
+// ============================================
+// pkg/csv/compression.go
+// ============================================
+package csv
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Magic numbers of the supported compression and archive formats.
+var (
+	gzipMagic     = []byte{0x1f, 0x8b}
+	zstdMagic     = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	zipMagic      = []byte("PK\x03\x04")
+	emptyZipMagic = []byte("PK\x05\x06")
+)
+
+// errZipArchive is returned by Open for zip archives, whose entries are
+// opened one by one with OpenEntry.
+var errZipArchive = errors.New("zip archives must be opened by entry")
+
+// decompress detects gzip and zstd input from its first bytes and returns
+// a reader of the decompressed data. Other input is returned unchanged.
+func decompress(r io.Reader) (io.ReadCloser, error) {
+	buffered := bufio.NewReader(r)
+	magic, err := buffered.Peek(len(zstdMagic))
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		return gzip.NewReader(buffered)
+	case bytes.HasPrefix(magic, zstdMagic):
+		decoder, err := zstd.NewReader(buffered)
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	case isZipMagic(magic):
+		return nil, errZipArchive
+	default:
+		return io.NopCloser(buffered), nil
+	}
+}
+
+func isZipMagic(magic []byte) bool {
+	return bytes.HasPrefix(magic, zipMagic) || bytes.HasPrefix(magic, emptyZipMagic)
+}
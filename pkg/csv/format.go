@@ -0,0 +1,101 @@
+// ============================================
+// pkg/csv/format.go
+// ============================================
+package csv
+
+import (
+	"bufio"
+	"bytes"
+	"mime"
+	"path/filepath"
+	"strings"
+)
+
+// Format identifies how the records of an import file are encoded.
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatXLSX   Format = "xlsx"
+	FormatJSON   Format = "json"
+	FormatNDJSON Format = "ndjson"
+)
+
+var byteOrderMark = []byte("\ufeff")
+
+// formatExtensions maps file extensions to formats.
+var formatExtensions = map[string]Format{
+	".csv":    FormatCSV,
+	".xlsx":   FormatXLSX,
+	".json":   FormatJSON,
+	".ndjson": FormatNDJSON,
+	".jsonl":  FormatNDJSON,
+}
+
+// compressionExtensions are skipped when detecting the format, as in
+// "products.json.gz".
+var compressionExtensions = map[string]bool{
+	".gz":  true,
+	".zst": true,
+}
+
+// contentTypes maps media types to formats.
+var contentTypes = map[string]Format{
+	"text/csv":             FormatCSV,
+	"application/csv":      FormatCSV,
+	"application/json":     FormatJSON,
+	"application/x-ndjson": FormatNDJSON,
+	"application/ndjson":   FormatNDJSON,
+	"application/jsonl":    FormatNDJSON,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": FormatXLSX,
+}
+
+// DetectFormat returns the format named by the extension of name, or ""
+// when the extension is not recognised.
+func DetectFormat(name string) Format {
+	ext := strings.ToLower(filepath.Ext(name))
+	if compressionExtensions[ext] {
+		ext = strings.ToLower(filepath.Ext(strings.TrimSuffix(name, filepath.Ext(name))))
+	}
+	return formatExtensions[ext]
+}
+
+// FormatForContentType returns the format of a media type such as
+// "application/x-ndjson; charset=utf-8", or "" when it is not recognised.
+func FormatForContentType(contentType string) Format {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return contentTypes[mediaType]
+}
+
+// Extension returns the file extension of the format.
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+// sniffFormat tells JSON from CSV by the first character of the input.
+func sniffFormat(input *bufio.Reader) Format {
+	switch firstChar(input) {
+	case '[', '{':
+		return FormatJSON
+	default:
+		return FormatCSV
+	}
+}
+
+// firstChar returns the first character of input after any whitespace,
+// discarding a leading byte order mark.
+func firstChar(input *bufio.Reader) byte {
+	if bom, _ := input.Peek(len(byteOrderMark)); bytes.Equal(bom, byteOrderMark) {
+		input.Discard(len(byteOrderMark))
+	}
+
+	head, _ := input.Peek(512)
+	head = bytes.TrimLeft(head, " \t\r\n")
+	if len(head) == 0 {
+		return 0
+	}
+	return head[0]
+}
@@ -0,0 +1,36 @@
+// ============================================
+// pkg/csv/format_test.go
+// ============================================
+package csv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := map[string]Format{
+		"products.csv":     FormatCSV,
+		"Products.XLSX":    FormatXLSX,
+		"feed.json":        FormatJSON,
+		"feed.jsonl":       FormatNDJSON,
+		"feed.ndjson.gz":   FormatNDJSON,
+		"products.csv.zst": FormatCSV,
+		"products.txt":     "",
+		"products":         "",
+		"products.gz":      "",
+	}
+
+	for name, want := range tests {
+		assert.Equal(t, want, DetectFormat(name), name)
+	}
+}
+
+func TestFormatForContentType(t *testing.T) {
+	assert.Equal(t, FormatNDJSON, FormatForContentType("application/x-ndjson; charset=utf-8"))
+	assert.Equal(t, FormatJSON, FormatForContentType("application/json"))
+	assert.Equal(t, FormatCSV, FormatForContentType("text/csv"))
+	assert.Equal(t, Format(""), FormatForContentType("application/octet-stream"))
+	assert.Equal(t, Format(""), FormatForContentType(""))
+}
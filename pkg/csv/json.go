@@ -0,0 +1,199 @@
+// ============================================
+// pkg/csv/json.go
+// ============================================
+package csv
+
+import (
+	"bufio"
+	"bytes"
+	"data-processing/internal/domain"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// JSONStream reads records from a JSON array of objects or from
+// newline-delimited JSON objects, one per line. Object keys are matched to
+// columns like CSV headers and unknown keys are ignored.
+type JSONStream struct {
+	closers []io.Closer
+	// decoder reads an array, lines a newline-delimited feed
+	decoder *json.Decoder
+	lines   *bufio.Reader
+	offset  func() int64
+	aliases map[string]Column
+	options Options
+	size    int64
+	array   bool
+	row     int
+}
+
+// openJSON positions the stream on the first object of input.
+func (r *Reader) openJSON(input io.Reader, counter *countingReader, size int64, opts Options, closers ...io.Closer) (*JSONStream, error) {
+	decoded, err := opts.decode(input)
+	if err != nil {
+		closeAll(closers)
+		return nil, err
+	}
+
+	buffered := bufio.NewReader(decoded)
+	stream := &JSONStream{
+		closers: closers,
+		offset:  counter.offset,
+		aliases: r.withAliases(opts.Aliases),
+		options: opts,
+		size:    size,
+	}
+
+	// A feed is either one array or a sequence of objects, one per line
+	if firstChar(buffered) != '[' {
+		stream.lines = buffered
+		return stream, nil
+	}
+
+	stream.decoder = json.NewDecoder(buffered)
+	stream.decoder.UseNumber()
+	if _, err := stream.decoder.Token(); err != nil {
+		stream.Close()
+		return nil, err
+	}
+	stream.array = true
+
+	return stream, nil
+}
+
+// Next returns the next record, or io.EOF once the feed is exhausted. The
+// record's RowNumber is the position of the object in the feed. A value
+// that is not an object, or a line of a newline-delimited feed that is not
+// valid JSON, is returned as a *domain.RowError and the following call
+// continues after it.
+func (s *JSONStream) Next() (*domain.CSVRecord, error) {
+	var object map[string]interface{}
+	var err error
+	if s.array {
+		object, err = s.nextElement()
+	} else {
+		object, err = s.nextLine()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	record := &domain.CSVRecord{RowNumber: s.row}
+	seen := make(map[Column]string, len(object))
+	for key, value := range object {
+		name := normalizeHeader(key)
+		column, ok := s.aliases[name]
+		if !ok {
+			column = Column(name)
+			if !isKnownColumn(column) {
+				continue
+			}
+		}
+
+		if other, exists := seen[column]; exists {
+			return nil, &domain.RowError{
+				Row: s.row,
+				Err: fmt.Errorf("duplicate fields for column %s: %q and %q", column, other, key),
+			}
+		}
+		seen[column] = key
+		*recordField(record, column) = jsonText(value)
+	}
+	s.options.apply(record)
+
+	return record, nil
+}
+
+// nextElement decodes the next object of an array.
+func (s *JSONStream) nextElement() (map[string]interface{}, error) {
+	if !s.decoder.More() {
+		return nil, io.EOF
+	}
+
+	var object map[string]interface{}
+	if err := s.decoder.Decode(&object); err != nil {
+		// A value that is not an object is consumed, unlike a syntax error
+		// which leaves the decoder unable to find the next element
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			s.row++
+			return nil, &domain.RowError{Row: s.row, Err: objectError(err)}
+		}
+		return nil, err
+	}
+	s.row++
+	return object, nil
+}
+
+// nextLine decodes the object on the next non-blank line. Each line is
+// decoded on its own, so a broken line does not end the feed.
+func (s *JSONStream) nextLine() (map[string]interface{}, error) {
+	for {
+		line, err := s.lines.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			if err == io.EOF {
+				return nil, io.EOF
+			}
+			continue
+		}
+		s.row++
+
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.UseNumber()
+		var object map[string]interface{}
+		if err := decoder.Decode(&object); err != nil {
+			return nil, &domain.RowError{Row: s.row, Err: objectError(err)}
+		}
+		if _, err := decoder.Token(); err != io.EOF {
+			return nil, &domain.RowError{Row: s.row, Err: errors.New("unexpected data after the object")}
+		}
+		return object, nil
+	}
+}
+
+// objectError describes why a value could not be decoded as an object.
+func objectError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return fmt.Errorf("expected an object, got %s", typeErr.Value)
+	}
+	return fmt.Errorf("invalid JSON: %w", err)
+}
+
+// jsonText returns a decoded JSON value as field text. Nested values keep
+// their JSON form.
+func jsonText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		text, _ := json.Marshal(v)
+		return string(text)
+	}
+}
+
+// Offset returns the number of bytes consumed from the file so far.
+func (s *JSONStream) Offset() int64 {
+	return s.offset()
+}
+
+// Size returns the size of the file in bytes.
+func (s *JSONStream) Size() int64 {
+	return s.size
+}
+
+func (s *JSONStream) Close() error {
+	return closeAll(s.closers)
+}
@@ -0,0 +1,180 @@
+// ============================================
+// pkg/csv/json_test.go
+// ============================================
+package csv
+
+import (
+	"data-processing/internal/domain"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFeed(t *testing.T, name, content string) string {
+	filePath := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0o644))
+	return filePath
+}
+
+func TestReader_OpenJSON(t *testing.T) {
+	t.Run("success - array", func(t *testing.T) {
+		filePath := writeTestFeed(t, "products.json", `[
+			{"id": 1, "name": "Fan", "price": 10.5, "stock": 5, "internal_id": 7, "tags": ["a"], "unknown": true},
+			{"SKU": "2", "Title": "Mouse", "Price": "20", "Qty": 6, "Internal ID": 8, "ean": null}
+		]`)
+
+		stream, err := NewReader(nil).Open(filePath, Options{})
+		require.NoError(t, err)
+		defer stream.Close()
+
+		record, err := stream.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "1", record.ID)
+		assert.Equal(t, "Fan", record.Name)
+		assert.Equal(t, "10.5", record.Price)
+		assert.Equal(t, "5", record.Stock)
+		assert.Equal(t, "7", record.InternalId)
+		assert.Equal(t, 1, record.RowNumber)
+
+		record, err = stream.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "2", record.ID)
+		assert.Equal(t, "Mouse", record.Name)
+		assert.Equal(t, "6", record.Stock)
+		assert.Empty(t, record.Ean)
+		assert.Equal(t, 2, record.RowNumber)
+
+		_, err = stream.Next()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("success - ndjson with options", func(t *testing.T) {
+		filePath := writeTestFeed(t, "products.ndjson",
+			`{"Artikel": 1, "name": "Fan", "price": "1.234,50", "currency": " eur "}`+"\n\n"+
+				`{"Artikel": 2, "name": "Mouse", "available": false}`+"\n")
+
+		stream, err := NewReader(nil).Open(filePath, Options{
+			Aliases:          map[string]Column{"Artikel": ColumnID, "available": ColumnAvailability},
+			DecimalSeparator: ",",
+			Transforms:       map[Column][]string{ColumnCurrency: {"trim", "upper"}},
+		})
+		require.NoError(t, err)
+		defer stream.Close()
+
+		record, err := stream.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "1", record.ID)
+		assert.Equal(t, "1234.50", record.Price)
+		assert.Equal(t, "EUR", record.Currency)
+
+		record, err = stream.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "2", record.ID)
+		assert.Equal(t, "false", record.Availability)
+
+		_, err = stream.Next()
+		assert.Equal(t, io.EOF, err)
+		assert.Equal(t, stream.Size(), stream.Offset())
+	})
+
+	t.Run("success - detected from content", func(t *testing.T) {
+		filePath := writeTestFeed(t, "products.txt", "\ufeff"+`[{"id": 1, "name": "Fan"}]`)
+
+		stream, err := NewReader(nil).Open(filePath, Options{})
+		require.NoError(t, err)
+		defer stream.Close()
+
+		record, err := stream.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "Fan", record.Name)
+	})
+
+	t.Run("error - duplicate column", func(t *testing.T) {
+		filePath := writeTestFeed(t, "products.ndjson", `{"id": 1, "sku": 2}`)
+
+		stream, err := NewReader(nil).Open(filePath, Options{})
+		require.NoError(t, err)
+		defer stream.Close()
+
+		_, err = stream.Next()
+		var rowErr *domain.RowError
+		require.ErrorAs(t, err, &rowErr)
+		assert.Equal(t, 1, rowErr.Row)
+		assert.Contains(t, err.Error(), "duplicate fields for column id")
+	})
+
+	t.Run("error - value that is not an object", func(t *testing.T) {
+		filePath := writeTestFeed(t, "products.json", `[42, {"id": 1, "name": "Fan"}]`)
+
+		stream, err := NewReader(nil).Open(filePath, Options{})
+		require.NoError(t, err)
+		defer stream.Close()
+
+		_, err = stream.Next()
+		var rowErr *domain.RowError
+		require.ErrorAs(t, err, &rowErr)
+		assert.Equal(t, 1, rowErr.Row)
+
+		// The stream continues with the next object
+		record, err := stream.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "Fan", record.Name)
+		assert.Equal(t, 2, record.RowNumber)
+	})
+
+	t.Run("error - broken ndjson lines", func(t *testing.T) {
+		filePath := writeTestFeed(t, "products.ndjson",
+			`{"id": 1, "name": "Fan"}`+"\n"+
+				`{"id": 2, "name": "Mou`+"\n"+
+				`42`+"\n\n"+
+				`{"id": 4} {"id": 5}`+"\n"+
+				`{"id": 6, "name": "Lamp"}`)
+
+		stream, err := NewReader(nil).Open(filePath, Options{})
+		require.NoError(t, err)
+		defer stream.Close()
+
+		record, err := stream.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "Fan", record.Name)
+
+		for _, expected := range []struct {
+			row     int
+			message string
+		}{
+			{2, "invalid JSON"},
+			{3, "expected an object, got number"},
+			{4, "unexpected data after the object"},
+		} {
+			_, err = stream.Next()
+			var rowErr *domain.RowError
+			require.ErrorAs(t, err, &rowErr)
+			assert.Equal(t, expected.row, rowErr.Row)
+			assert.Contains(t, err.Error(), expected.message)
+		}
+
+		// The stream continues with the next line
+		record, err = stream.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "Lamp", record.Name)
+		assert.Equal(t, 5, record.RowNumber)
+
+		_, err = stream.Next()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("error - malformed", func(t *testing.T) {
+		filePath := writeTestFeed(t, "products.json", `[{"id": 1,]`)
+
+		stream, err := NewReader(nil).Open(filePath, Options{})
+		require.NoError(t, err)
+		defer stream.Close()
+
+		_, err = stream.Next()
+		assert.Error(t, err)
+	})
+}
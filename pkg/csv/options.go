@@ -0,0 +1,248 @@
+// ============================================
+// pkg/csv/options.go
+// ============================================
+package csv
+
+import (
+	"bufio"
+	"data-processing/internal/domain"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// Options customise how a file is decoded. The zero value reads
+// comma-delimited UTF-8 using the reader's aliases.
+type Options struct {
+	// Aliases maps headers to columns on top of the reader's aliases.
+	Aliases map[string]Column
+	// Delimiter separates fields, defaults to ','.
+	Delimiter rune
+	// Quote encloses fields holding delimiters or line breaks, defaults
+	// to '"'.
+	Quote rune
+	// Comment starts lines that are skipped, none by default.
+	Comment rune
+	// LazyQuotes accepts quotes inside unquoted fields and unescaped
+	// quotes inside quoted fields.
+	LazyQuotes bool
+	// Encoding is the source character set, e.g. "windows-1252". Empty or
+	// "auto" detects UTF-8 and UTF-16 and falls back to windows-1252. A
+	// byte order mark always wins and is stripped.
+	Encoding string
+	// DecimalSeparator is used in price and stock values, defaults to '.'.
+	DecimalSeparator string
+	// Transforms are applied in order to the value of each column.
+	Transforms map[Column][]string
+	// Sheet selects a workbook sheet by name or 1-based position,
+	// defaults to the first sheet.
+	Sheet string
+	// HeaderRow is the 1-based row holding the header, defaults to 1.
+	// Rows above it are skipped.
+	HeaderRow int
+}
+
+// transforms holds the supported per-field transforms.
+var transforms = map[string]func(string) string{
+	"trim":  strings.TrimSpace,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"collapse_spaces": func(value string) string {
+		return strings.Join(strings.Fields(value), " ")
+	},
+	"digits_only": func(value string) string {
+		return strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, value)
+	},
+}
+
+// ParseChar returns the single character in value, such as a delimiter,
+// or zero when value is empty.
+func ParseChar(value string) (rune, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	r, size := utf8.DecodeRuneInString(value)
+	if size != len(value) || !validChar(r) {
+		return 0, fmt.Errorf("invalid character %q", value)
+	}
+	return r, nil
+}
+
+func validChar(r rune) bool {
+	return r != '\r' && r != '\n' && r != utf8.RuneError
+}
+
+// Validate reports the first option that cannot be applied.
+func (o Options) Validate() error {
+	delimiter, quote := o.delimiter(), o.quote()
+	if !validChar(delimiter) {
+		return fmt.Errorf("invalid delimiter %q", delimiter)
+	}
+	if !validChar(quote) {
+		return fmt.Errorf("invalid quote %q", quote)
+	}
+	if delimiter == quote {
+		return fmt.Errorf("delimiter and quote are both %q", delimiter)
+	}
+	if o.Comment != 0 && (!validChar(o.Comment) || o.Comment == delimiter || o.Comment == quote) {
+		return fmt.Errorf("invalid comment prefix %q", o.Comment)
+	}
+
+	if !autoEncoding(o.Encoding) {
+		if _, err := htmlindex.Get(o.Encoding); err != nil {
+			return fmt.Errorf("unsupported encoding %q", o.Encoding)
+		}
+	}
+
+	if o.HeaderRow < 0 {
+		return fmt.Errorf("invalid header row %d", o.HeaderRow)
+	}
+
+	switch o.DecimalSeparator {
+	case "", ".", ",":
+	default:
+		return fmt.Errorf("invalid decimal separator %q", o.DecimalSeparator)
+	}
+
+	for column, names := range o.Transforms {
+		if !isKnownColumn(column) {
+			return fmt.Errorf("unknown column %q", column)
+		}
+		for _, name := range names {
+			if _, ok := transforms[name]; !ok {
+				return fmt.Errorf("unknown transform %q for column %s", name, column)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (o Options) delimiter() rune {
+	if o.Delimiter == 0 {
+		return ','
+	}
+	return o.Delimiter
+}
+
+func (o Options) quote() rune {
+	if o.Quote == 0 {
+		return '"'
+	}
+	return o.Quote
+}
+
+func autoEncoding(name string) bool {
+	return name == "" || strings.EqualFold(name, "auto")
+}
+
+// sniffSize is how much of a file is checked when detecting its encoding.
+const sniffSize = 64 << 10
+
+// decode wraps r so it yields UTF-8 without a byte order mark from the
+// configured or detected source encoding.
+func (o Options) decode(r io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReaderSize(r, sniffSize)
+
+	var enc encoding.Encoding
+	if autoEncoding(o.Encoding) {
+		enc = detectEncoding(buffered)
+	} else {
+		var err error
+		enc, err = htmlindex.Get(o.Encoding)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported encoding %q", o.Encoding)
+		}
+	}
+
+	return transform.NewReader(buffered, unicode.BOMOverride(enc.NewDecoder())), nil
+}
+
+// detectEncoding picks UTF-8 when the start of input is valid UTF-8 and
+// windows-1252, the usual encoding of spreadsheet exports, otherwise.
+// UTF-16 is recognised by its byte order mark when decoding.
+func detectEncoding(input *bufio.Reader) encoding.Encoding {
+	head, _ := input.Peek(sniffSize)
+	if utf8.Valid(trimPartialRune(head)) {
+		return unicode.UTF8
+	}
+	return charmap.Windows1252
+}
+
+// trimPartialRune drops a character cut off at the end of b.
+func trimPartialRune(b []byte) []byte {
+	for i := 1; i <= utf8.UTFMax && i <= len(b); i++ {
+		if utf8.RuneStart(b[len(b)-i]) {
+			if !utf8.FullRune(b[len(b)-i:]) {
+				return b[:len(b)-i]
+			}
+			return b
+		}
+	}
+	return b
+}
+
+// apply runs the configured transforms and number normalisation on record.
+func (o Options) apply(record *domain.CSVRecord) {
+	for column, names := range o.Transforms {
+		field := recordField(record, column)
+		for _, name := range names {
+			*field = transforms[name](*field)
+		}
+	}
+
+	if o.DecimalSeparator == "," {
+		record.Price = normalizeDecimal(record.Price)
+		record.Stock = normalizeDecimal(record.Stock)
+	}
+}
+
+// normalizeDecimal converts "1.234,50" into "1234.50".
+func normalizeDecimal(value string) string {
+	value = strings.NewReplacer(".", "", " ", "").Replace(value)
+	return strings.Replace(value, ",", ".", 1)
+}
+
+func recordField(record *domain.CSVRecord, column Column) *string {
+	switch column {
+	case ColumnID:
+		return &record.ID
+	case ColumnName:
+		return &record.Name
+	case ColumnDescription:
+		return &record.Description
+	case ColumnBrand:
+		return &record.Brand
+	case ColumnCategory:
+		return &record.Category
+	case ColumnPrice:
+		return &record.Price
+	case ColumnCurrency:
+		return &record.Currency
+	case ColumnStock:
+		return &record.Stock
+	case ColumnEan:
+		return &record.Ean
+	case ColumnColor:
+		return &record.Color
+	case ColumnSize:
+		return &record.Size
+	case ColumnAvailability:
+		return &record.Availability
+	default:
+		return &record.InternalId
+	}
+}
@@ -0,0 +1,210 @@
+// ============================================
+// pkg/csv/parser.go
+// ============================================
+package csv
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxQuotedLines bounds how many lines a quoted field may span before its
+// opening quote is taken to be unterminated.
+const maxQuotedLines = 1000
+
+// parser reads delimited records like encoding/csv, which cannot change
+// its quote character, with a configurable quote. Errors are returned as
+// *csv.ParseError so they read the same; the record in error is consumed,
+// so the next Read continues on the following line.
+type parser struct {
+	r          *bufio.Reader
+	comma      rune
+	quote      rune
+	comment    rune
+	lazyQuotes bool
+	// fieldsPerRecord is checked against every record when positive;
+	// records of another length are returned with csv.ErrFieldCount
+	fieldsPerRecord int
+
+	line    int
+	current string
+	field   strings.Builder
+	// replay holds lines to read again after an unterminated quote
+	replay []string
+}
+
+func newParser(r io.Reader, opts Options) *parser {
+	p := &parser{
+		r:               bufio.NewReader(r),
+		comma:           ',',
+		quote:           '"',
+		comment:         opts.Comment,
+		lazyQuotes:      opts.LazyQuotes,
+		fieldsPerRecord: -1,
+	}
+	if opts.Delimiter != 0 {
+		p.comma = opts.Delimiter
+	}
+	if opts.Quote != 0 {
+		p.quote = opts.Quote
+	}
+	return p
+}
+
+// readLine returns the next line without its line ending, or io.EOF.
+func (p *parser) readLine() (string, error) {
+	if len(p.replay) > 0 {
+		p.line++
+		p.current, p.replay = p.replay[0], p.replay[1:]
+		return p.current, nil
+	}
+
+	line, err := p.r.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	if err != nil {
+		return "", err
+	}
+	p.line++
+
+	line = strings.TrimSuffix(line, "\n")
+	p.current = strings.TrimSuffix(line, "\r")
+	return p.current, nil
+}
+
+// Read returns the fields of the next record, skipping empty lines and
+// comments.
+func (p *parser) Read() ([]string, error) {
+	var line string
+	for {
+		var err error
+		line, err = p.readLine()
+		if err != nil {
+			return nil, err
+		}
+		if line == "" {
+			continue
+		}
+		if p.comment != 0 {
+			if r, _ := utf8.DecodeRuneInString(line); r == p.comment {
+				continue
+			}
+		}
+		break
+	}
+
+	record, err := p.parseRecord(line)
+	if err != nil {
+		return nil, err
+	}
+	if p.fieldsPerRecord > 0 && len(record) != p.fieldsPerRecord {
+		return record, &csv.ParseError{
+			StartLine: p.line,
+			Line:      p.line,
+			Column:    1,
+			Err:       fmt.Errorf("%w: expected %d, got %d", csv.ErrFieldCount, p.fieldsPerRecord, len(record)),
+		}
+	}
+	return record, nil
+}
+
+// parseRecord splits line into fields, reading further lines while a
+// quoted field is open.
+func (p *parser) parseRecord(line string) ([]string, error) {
+	startLine := p.line
+	var record []string
+
+	for {
+		p.field.Reset()
+
+		// Unquoted field
+		r, size := utf8.DecodeRuneInString(line)
+		if line == "" || r != p.quote {
+			end := strings.IndexRune(line, p.comma)
+			value := line
+			if end >= 0 {
+				value = line[:end]
+			}
+			if !p.lazyQuotes {
+				if i := strings.IndexRune(value, p.quote); i >= 0 {
+					return nil, p.parseError(startLine, line[i:], csv.ErrBareQuote)
+				}
+			}
+			record = append(record, value)
+			if end < 0 {
+				return record, nil
+			}
+			line = line[end+utf8.RuneLen(p.comma):]
+			continue
+		}
+		line = line[size:]
+
+		// Quoted field, which may span lines
+		var continued []string
+		for {
+			i := strings.IndexRune(line, p.quote)
+			if i < 0 {
+				p.field.WriteString(line)
+				next, err := p.readLine()
+				if err == io.EOF && p.lazyQuotes {
+					return append(record, p.field.String()), nil
+				}
+				if err == io.EOF || len(continued) == maxQuotedLines {
+					// Resume on the line after the opening quote
+					quoteErr := p.parseError(startLine, "", csv.ErrQuote)
+					if err == nil {
+						continued = append(continued, next)
+					}
+					p.replay = append(continued, p.replay...)
+					p.line = startLine
+					return nil, quoteErr
+				}
+				if err != nil {
+					return nil, err
+				}
+				continued = append(continued, next)
+				p.field.WriteByte('\n')
+				line = next
+				continue
+			}
+
+			p.field.WriteString(line[:i])
+			line = line[i+utf8.RuneLen(p.quote):]
+			next, size := utf8.DecodeRuneInString(line)
+			switch {
+			case line != "" && next == p.quote:
+				// Doubled quote
+				p.field.WriteRune(p.quote)
+				line = line[size:]
+				continue
+			case line == "":
+				return append(record, p.field.String()), nil
+			case next == p.comma:
+				record = append(record, p.field.String())
+				line = line[size:]
+			case p.lazyQuotes:
+				p.field.WriteRune(p.quote)
+				continue
+			default:
+				return nil, p.parseError(startLine, line, csv.ErrQuote)
+			}
+			break
+		}
+	}
+}
+
+// parseError reports err at the start of rest, the unparsed end of the
+// current line.
+func (p *parser) parseError(startLine int, rest string, err error) error {
+	return &csv.ParseError{
+		StartLine: startLine,
+		Line:      p.line,
+		Column:    utf8.RuneCountInString(p.current[:len(p.current)-len(rest)]) + 1,
+		Err:       err,
+	}
+}
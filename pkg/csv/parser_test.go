@@ -0,0 +1,105 @@
+// ============================================
+// pkg/csv/parser_test.go
+// ============================================
+package csv
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readAll returns every record parsed from content, stopping at the first
+// error.
+func readAll(t *testing.T, content string, opts Options) ([][]string, error) {
+	p := newParser(strings.NewReader(content), opts)
+
+	var records [][]string
+	for {
+		record, err := p.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+}
+
+func TestParser_Read(t *testing.T) {
+	t.Run("success - quoted fields", func(t *testing.T) {
+		records, err := readAll(t, "a,\"b,c\",\"say \"\"hi\"\"\"\r\n\n\"multi\nline\",x,\n", Options{})
+
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"a", "b,c", `say "hi"`},
+			{"multi\nline", "x", ""},
+		}, records)
+	})
+
+	t.Run("success - custom quote and comments", func(t *testing.T) {
+		records, err := readAll(t, "# exported 2024-01-01\n'a;b';'it''s'\n", Options{
+			Delimiter: ';',
+			Quote:     '\'',
+			Comment:   '#',
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"a;b", "it's"}}, records)
+	})
+
+	t.Run("success - lazy quotes", func(t *testing.T) {
+		records, err := readAll(t, "12\" fan,\"a \"b\" c\"\n", Options{LazyQuotes: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{`12" fan`, `a "b" c`}}, records)
+	})
+
+	t.Run("error - bare quote", func(t *testing.T) {
+		_, err := readAll(t, "a,12\" fan\n", Options{})
+
+		var parseErr *csv.ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.ErrorIs(t, err, csv.ErrBareQuote)
+		assert.Equal(t, 1, parseErr.Line)
+		assert.Equal(t, 5, parseErr.Column)
+	})
+
+	t.Run("error - unterminated quote continues on next line", func(t *testing.T) {
+		p := newParser(strings.NewReader("a,b\n\"open,c\nd,e\n"), Options{})
+
+		_, err := p.Read()
+		require.NoError(t, err)
+
+		_, err = p.Read()
+		var parseErr *csv.ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.ErrorIs(t, err, csv.ErrQuote)
+		assert.Equal(t, 2, parseErr.StartLine)
+
+		record, err := p.Read()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"d", "e"}, record)
+		assert.Equal(t, 3, p.line)
+	})
+
+	t.Run("error - field count continues on next line", func(t *testing.T) {
+		p := newParser(strings.NewReader("a,b\nc\nd,e\n"), Options{})
+		p.fieldsPerRecord = 2
+
+		_, err := p.Read()
+		require.NoError(t, err)
+
+		_, err = p.Read()
+		assert.ErrorIs(t, err, csv.ErrFieldCount)
+
+		record, err := p.Read()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"d", "e"}, record)
+	})
+}
@@ -4,64 +4,254 @@
 package csv
 
 import (
+	"bufio"
 	"data-processing/internal/domain"
 	"encoding/csv"
-	"log"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 )
 
-type Reader struct{}
+type Reader struct {
+	aliases map[string]Column
+}
+
+// NewReader creates a reader that resolves headers with DefaultAliases
+// extended by the given aliases.
+func NewReader(aliases map[string]Column) *Reader {
+	merged := make(map[string]Column, len(DefaultAliases)+len(aliases))
+	for header, column := range DefaultAliases {
+		merged[header] = column
+	}
+	for header, column := range aliases {
+		merged[normalizeHeader(header)] = column
+	}
+
+	return &Reader{aliases: merged}
+}
+
+// withAliases returns the reader aliases extended by extra.
+func (r *Reader) withAliases(extra map[string]Column) map[string]Column {
+	if len(extra) == 0 {
+		return r.aliases
+	}
+
+	merged := make(map[string]Column, len(r.aliases)+len(extra))
+	for header, column := range r.aliases {
+		merged[header] = column
+	}
+	for header, column := range extra {
+		merged[normalizeHeader(header)] = column
+	}
+	return merged
+}
+
+// Stream reads CSV records one row at a time so memory use does not
+// depend on the size of the file.
+type Stream struct {
+	closers []io.Closer
+	rows    rowReader
+	offset  func() int64
+	columns columnMap
+	options Options
+	size    int64
+	row     int
+}
+
+// rowReader yields the raw fields of each row, such as a *csv.Reader.
+type rowReader interface {
+	Read() ([]string, error)
+}
+
+// countingReader tracks how many bytes have been read from the file, which
+// may differ from the decoded bytes seen by the CSV parser.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
 
-func NewReader() *Reader {
-	return &Reader{}
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
 }
 
-func (r *Reader) ReadCSV(filePath string) ([]*domain.CSVRecord, error) {
-	currentDir, err := os.Getwd()
+func (c *countingReader) offset() int64 {
+	return c.n
+}
+
+// Open opens the CSV, XLSX or JSON file at filePath, which callers resolve
+// beforehand, and positions the source on the first record. The format is
+// taken from the extension, or detected from the content when the
+// extension is not recognised. Gzip and zstd files are decompressed on the
+// fly; progress is measured against the compressed size.
+func (r *Reader) Open(filePath string, opts Options) (domain.RecordSource, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
-	file, err := os.Open(currentDir + filePath)
+	info, err := file.Stat()
 	if err != nil {
+		file.Close()
 		return nil, err
 	}
-	defer file.Close()
 
-	reader := csv.NewReader(file)
-	records, err := reader.ReadAll()
+	magic := make([]byte, len(zipMagic))
+	n, _ := file.ReadAt(magic, 0)
+	if isZipMagic(magic[:n]) && isWorkbook(file, info.Size()) {
+		file.Close()
+		return r.openWorkbook(filePath, info.Size(), opts)
+	}
+
+	counter := &countingReader{r: file}
+	input, err := decompress(counter)
 	if err != nil {
+		file.Close()
 		return nil, err
 	}
 
-	var csvRecords []*domain.CSVRecord
-	// Skip header row
-	for i, record := range records {
-		if i == 0 {
-			continue
+	return r.openText(DetectFormat(filePath), input, counter, info.Size(), opts, input, file)
+}
+
+// openText opens input in a text format, sniffing the format when it is
+// not known.
+func (r *Reader) openText(format Format, input io.Reader, counter *countingReader, size int64, opts Options, closers ...io.Closer) (domain.RecordSource, error) {
+	buffered := bufio.NewReader(input)
+	if format == "" {
+		format = sniffFormat(buffered)
+	}
+
+	switch format {
+	case FormatJSON, FormatNDJSON:
+		return r.openJSON(buffered, counter, size, opts, closers...)
+	default:
+		return r.openCSV(buffered, counter, size, opts, closers...)
+	}
+}
+
+// openCSV decodes input as CSV text in the configured encoding.
+func (r *Reader) openCSV(input io.Reader, counter *countingReader, size int64, opts Options, closers ...io.Closer) (*Stream, error) {
+	decoded, err := opts.decode(input)
+	if err != nil {
+		closeAll(closers)
+		return nil, err
+	}
+
+	// Rows above the header may have any length, the header sets the
+	// length of the data rows
+	reader := newParser(decoded, opts)
+	return r.open(reader, counter.offset, size, opts, closers...)
+}
+
+// open reads the header from rows and returns a stream that reports
+// progress from offset against size. The closers are closed in order when
+// the stream is closed, or right away when the header cannot be read.
+func (r *Reader) open(rows rowReader, offset func() int64, size int64, opts Options, closers ...io.Closer) (*Stream, error) {
+	stream := &Stream{
+		closers: closers,
+		rows:    rows,
+		offset:  offset,
+		options: opts,
+		size:    size,
+	}
+
+	// Skip rows above the header, such as titles in a spreadsheet
+	headerRow := max(opts.HeaderRow, 1)
+	var header []string
+	for stream.row < headerRow {
+		var err error
+		header, err = rows.Read()
+		if err != nil {
+			stream.Close()
+			if err == io.EOF {
+				return nil, fmt.Errorf("missing header row")
+			}
+			return nil, err
+		}
+		stream.row++
+	}
+
+	if reader, ok := rows.(*parser); ok {
+		reader.fieldsPerRecord = len(header)
+	}
+
+	var err error
+	stream.columns, err = resolveColumns(header, r.withAliases(opts.Aliases))
+	if err != nil {
+		stream.Close()
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+// Next returns the next record, or io.EOF once the file is exhausted.
+// Blank rows are skipped. A row with the wrong number of fields or broken
+// quoting is returned as a *domain.RowError, the following call continues
+// after it.
+func (s *Stream) Next() (*domain.CSVRecord, error) {
+	for {
+		record, err := s.rows.Read()
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				s.row++
+				return nil, &domain.RowError{Row: s.row, Err: parseErr}
+			}
+			return nil, err
 		}
+		s.row++
 
-		if len(record) < 5 {
+		if isBlank(record) {
 			continue
 		}
 
-		csvRecords = append(csvRecords, &domain.CSVRecord{
-			ID:           record[0],
-			Name:         record[1],
-			Description:  record[2],
-			Brand:        record[3],
-			Category:     record[4],
-			Price:        record[5],
-			Currency:     record[6],
-			Stock:        record[7],
-			Ean:          record[8],
-			Color:        record[9],
-			Size:         record[10],
-			Availability: record[11],
-			InternalId:   record[12],
-			RowNumber:    i + 1,
-		})
-	}
-
-	return csvRecords, nil
+		csvRecord := s.columns.toRecord(record, s.row)
+		s.options.apply(csvRecord)
+
+		return csvRecord, nil
+	}
+}
+
+// isBlank reports whether every field of record is empty, such as an
+// empty spreadsheet row.
+func isBlank(record []string) bool {
+	for _, field := range record {
+		if field != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Offset returns the number of bytes consumed from the file so far. For
+// workbooks it is estimated from the row reached.
+func (s *Stream) Offset() int64 {
+	return s.offset()
+}
+
+// Size returns the size of the file in bytes.
+func (s *Stream) Size() int64 {
+	return s.size
+}
+
+func (s *Stream) Close() error {
+	return closeAll(s.closers)
+}
+
+// closeAll closes every closer in order and returns the first error.
+func closeAll(closers []io.Closer) error {
+	var err error
+	for _, closer := range closers {
+		if closeErr := closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
 }
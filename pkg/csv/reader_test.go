@@ -0,0 +1,340 @@
+// ============================================
+// pkg/csv/reader_test.go
+// ============================================
+package csv
+
+import (
+	"bytes"
+	"compress/gzip"
+	"data-processing/internal/domain"
+	"encoding/csv"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testHeader = "Id,Name,Description,Brand,Category,Price,Currency,Stock,EAN,Color,Size,Availability,Internal ID\n"
+
+func writeTestCSV(t *testing.T, content string) string {
+	filePath := filepath.Join(t.TempDir(), "products.csv")
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0o644))
+	return filePath
+}
+
+func TestReader_Open(t *testing.T) {
+	t.Run("success - streams records", func(t *testing.T) {
+		filePath := writeTestCSV(t, testHeader+
+			"1,Fan,Desc,Brand,Category,10,USD,5,123,Red,M,in_stock,7\n"+
+			"2,Mouse,Desc,Brand,Category,20,USD,6,456,Blue,L,out_of_stock,8\n")
+
+		stream, err := NewReader(nil).Open(filePath, Options{})
+		require.NoError(t, err)
+		defer stream.Close()
+
+		record, err := stream.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "1", record.ID)
+		assert.Equal(t, "Fan", record.Name)
+		assert.Equal(t, "7", record.InternalId)
+		assert.Equal(t, 2, record.RowNumber)
+
+		record, err = stream.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "Mouse", record.Name)
+		assert.Equal(t, 3, record.RowNumber)
+
+		_, err = stream.Next()
+		assert.Equal(t, io.EOF, err)
+		assert.Equal(t, stream.Size(), stream.Offset())
+	})
+
+	t.Run("success - header only", func(t *testing.T) {
+		filePath := writeTestCSV(t, testHeader)
+
+		stream, err := NewReader(nil).Open(filePath, Options{})
+		require.NoError(t, err)
+		defer stream.Close()
+
+		_, err = stream.Next()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("success - reordered and extra columns", func(t *testing.T) {
+		filePath := writeTestCSV(t,
+			"Internal ID,Stock,Extra,Price,Name,Index\n"+
+				"7,5,ignored,10,Fan,1\n")
+
+		stream, err := NewReader(nil).Open(filePath, Options{})
+		require.NoError(t, err)
+		defer stream.Close()
+
+		record, err := stream.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "1", record.ID)
+		assert.Equal(t, "Fan", record.Name)
+		assert.Equal(t, "10", record.Price)
+		assert.Equal(t, "5", record.Stock)
+		assert.Equal(t, "7", record.InternalId)
+		assert.Empty(t, record.Brand)
+	})
+
+	t.Run("success - configured alias", func(t *testing.T) {
+		filePath := writeTestCSV(t,
+			"Article No,Name,Price,Stock,Internal ID\n"+
+				"42,Fan,10,5,7\n")
+
+		stream, err := NewReader(map[string]Column{"Article No": ColumnID}).Open(filePath, Options{})
+		require.NoError(t, err)
+		defer stream.Close()
+
+		record, err := stream.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "42", record.ID)
+	})
+
+	t.Run("success - options", func(t *testing.T) {
+		filePath := writeTestCSV(t,
+			"Artikel;Name;Price;Stock;Internal ID;Currency\n"+
+				"1;Caf\xe9 Cr\xe8me;1.234,50;1.000;7; eur \n")
+
+		stream, err := NewReader(nil).Open(filePath, Options{
+			Aliases:          map[string]Column{"Artikel": ColumnID},
+			Delimiter:        ';',
+			Encoding:         "windows-1252",
+			DecimalSeparator: ",",
+			Transforms:       map[Column][]string{ColumnCurrency: {"trim", "upper"}},
+		})
+		require.NoError(t, err)
+		defer stream.Close()
+
+		record, err := stream.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "1", record.ID)
+		assert.Equal(t, "Café Crème", record.Name)
+		assert.Equal(t, "1234.50", record.Price)
+		assert.Equal(t, "1000", record.Stock)
+		assert.Equal(t, "EUR", record.Currency)
+	})
+
+	t.Run("success - encoding detected", func(t *testing.T) {
+		utf16 := []byte{0xff, 0xfe}
+		for _, r := range "Id,Name,Price,Stock,Internal ID\n1,Café,10,5,7\n" {
+			utf16 = append(utf16, byte(r), byte(r>>8))
+		}
+
+		for name, data := range map[string]string{
+			"windows-1252": "Id,Name,Price,Stock,Internal ID\n1,Caf\xe9,10,5,7\n",
+			"utf-8 bom":    "\ufeffId,Name,Price,Stock,Internal ID\n1,Café,10,5,7\n",
+			"utf-16 bom":   string(utf16),
+		} {
+			t.Run(name, func(t *testing.T) {
+				filePath := writeTestCSV(t, data)
+
+				stream, err := NewReader(nil).Open(filePath, Options{Encoding: "auto"})
+				require.NoError(t, err)
+				defer stream.Close()
+
+				record, err := stream.Next()
+				require.NoError(t, err)
+				assert.Equal(t, "1", record.ID)
+				assert.Equal(t, "Café", record.Name)
+			})
+		}
+	})
+
+	t.Run("success - quote, comment and lazy quotes", func(t *testing.T) {
+		filePath := writeTestCSV(t, "# supplier export\n"+
+			"Id|Name|Price|Stock|Internal ID\n"+
+			"1|'Fan|Desk'|10|5|7\n"+
+			"2|12' Fan|20|6|8\n")
+
+		stream, err := NewReader(nil).Open(filePath, Options{Delimiter: '|', Quote: '\'', Comment: '#', LazyQuotes: true})
+		require.NoError(t, err)
+		defer stream.Close()
+
+		record, err := stream.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "Fan|Desk", record.Name)
+
+		record, err = stream.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "12' Fan", record.Name)
+	})
+
+	t.Run("success - gzip and zstd detected by content", func(t *testing.T) {
+		content := testHeader + "1,Fan,Desc,Brand,Category,10,USD,5,123,Red,M,in_stock,7\n"
+
+		var gzipped bytes.Buffer
+		gzipWriter := gzip.NewWriter(&gzipped)
+		_, err := gzipWriter.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, gzipWriter.Close())
+
+		zstdWriter, err := zstd.NewWriter(nil)
+		require.NoError(t, err)
+		zstded := zstdWriter.EncodeAll([]byte(content), nil)
+
+		// Neither file has a compression extension
+		for name, data := range map[string][]byte{"gzip": gzipped.Bytes(), "zstd": zstded} {
+			t.Run(name, func(t *testing.T) {
+				filePath := writeTestCSV(t, string(data))
+
+				stream, err := NewReader(nil).Open(filePath, Options{})
+				require.NoError(t, err)
+				defer stream.Close()
+
+				record, err := stream.Next()
+				require.NoError(t, err)
+				assert.Equal(t, "Fan", record.Name)
+
+				_, err = stream.Next()
+				assert.Equal(t, io.EOF, err)
+				assert.Equal(t, int64(len(data)), stream.Size())
+			})
+		}
+	})
+
+	t.Run("success - malformed rows are reported and skipped", func(t *testing.T) {
+		filePath := writeTestCSV(t, testHeader+
+			"1,Fan,Desc,Brand,Category,10,USD,5,123,Red,M,in_stock,7\n"+
+			"2,Short,Desc,Brand,Category\n"+
+			"3,12\" Fan,Desc,Brand,Category,10,USD,5,123,Red,M,in_stock,9\n"+
+			",,,,,,,,,,,,\n"+
+			"4,Mouse,Desc,Brand,Category,20,USD,6,456,Blue,L,out_of_stock,8\n")
+
+		stream, err := NewReader(nil).Open(filePath, Options{})
+		require.NoError(t, err)
+		defer stream.Close()
+
+		record, err := stream.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "Fan", record.Name)
+
+		var rowErr *domain.RowError
+		_, err = stream.Next()
+		require.ErrorAs(t, err, &rowErr)
+		assert.Equal(t, 3, rowErr.Row)
+		assert.ErrorIs(t, err, csv.ErrFieldCount)
+		assert.Contains(t, err.Error(), "expected 13, got 5")
+
+		_, err = stream.Next()
+		require.ErrorAs(t, err, &rowErr)
+		assert.Equal(t, 4, rowErr.Row)
+		assert.ErrorIs(t, err, csv.ErrBareQuote)
+
+		// The blank row is skipped
+		record, err = stream.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "Mouse", record.Name)
+		assert.Equal(t, 6, record.RowNumber)
+
+		_, err = stream.Next()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("error - zip archive", func(t *testing.T) {
+		filePath := writeTestZip(t, map[string]string{"products.csv": testHeader})
+
+		stream, err := NewReader(nil).Open(filePath, Options{})
+
+		assert.ErrorIs(t, err, errZipArchive)
+		assert.Nil(t, stream)
+	})
+
+	t.Run("success - header row below a title", func(t *testing.T) {
+		filePath := writeTestCSV(t, "Supplier price list\n"+testHeader+
+			"1,Fan,Desc,Brand,Category,10,USD,5,123,Red,M,in_stock,7\n")
+
+		stream, err := NewReader(nil).Open(filePath, Options{HeaderRow: 2})
+		require.NoError(t, err)
+		defer stream.Close()
+
+		record, err := stream.Next()
+		require.NoError(t, err)
+		assert.Equal(t, "Fan", record.Name)
+		assert.Equal(t, 3, record.RowNumber)
+	})
+
+	t.Run("error - invalid options", func(t *testing.T) {
+		filePath := writeTestCSV(t, testHeader)
+
+		stream, err := NewReader(nil).Open(filePath, Options{Encoding: "klingon"})
+
+		assert.Error(t, err)
+		assert.Nil(t, stream)
+	})
+
+	t.Run("error - delimiter and quote clash", func(t *testing.T) {
+		filePath := writeTestCSV(t, testHeader)
+
+		stream, err := NewReader(nil).Open(filePath, Options{Delimiter: '"'})
+
+		assert.Error(t, err)
+		assert.Nil(t, stream)
+	})
+
+	t.Run("error - missing required column", func(t *testing.T) {
+		filePath := writeTestCSV(t, "Id,Name,Price\n1,Fan,10\n")
+
+		stream, err := NewReader(nil).Open(filePath, Options{})
+
+		assert.Error(t, err)
+		assert.Nil(t, stream)
+		assert.Contains(t, err.Error(), "missing required column(s): internal_id, stock")
+	})
+
+	t.Run("error - duplicate column", func(t *testing.T) {
+		filePath := writeTestCSV(t, "Id,Index,Name,Price,Stock,Internal ID\n")
+
+		stream, err := NewReader(nil).Open(filePath, Options{})
+
+		assert.Error(t, err)
+		assert.Nil(t, stream)
+		assert.Contains(t, err.Error(), "duplicate header")
+	})
+
+	t.Run("error - empty file", func(t *testing.T) {
+		filePath := writeTestCSV(t, "")
+
+		stream, err := NewReader(nil).Open(filePath, Options{})
+
+		assert.Error(t, err)
+		assert.Nil(t, stream)
+	})
+
+	t.Run("error - file not found", func(t *testing.T) {
+		stream, err := NewReader(nil).Open(filepath.Join(t.TempDir(), "missing.csv"), Options{})
+
+		assert.Error(t, err)
+		assert.Nil(t, stream)
+	})
+}
+
+func TestParseAliases(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		aliases, err := ParseAliases("Article No:id, Internal Code:internal_id")
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]Column{
+			"article_no":    ColumnID,
+			"internal_code": ColumnInternalId,
+		}, aliases)
+	})
+
+	t.Run("error - unknown column", func(t *testing.T) {
+		_, err := ParseAliases("Article No:article")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("error - invalid format", func(t *testing.T) {
+		_, err := ParseAliases("Article No")
+
+		assert.Error(t, err)
+	})
+}
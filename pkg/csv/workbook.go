@@ -0,0 +1,122 @@
+// ============================================
+// pkg/csv/workbook.go
+// ============================================
+package csv
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// workbookPart is present in every XLSX workbook, which is a zip archive.
+const workbookPart = "xl/workbook.xml"
+
+// isWorkbook reports whether the zip archive in r is an XLSX workbook.
+func isWorkbook(r io.ReaderAt, size int64) bool {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return false
+	}
+	for _, file := range archive.File {
+		if file.Name == workbookPart {
+			return true
+		}
+	}
+	return false
+}
+
+// workbookRows reads the rows of one sheet with raw cell values, so number
+// formats such as currency symbols do not end up in prices.
+type workbookRows struct {
+	rows *excelize.Rows
+	row  int
+}
+
+func (w *workbookRows) Read() ([]string, error) {
+	if !w.rows.Next() {
+		if err := w.rows.Error(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	w.row++
+	return w.rows.Columns(excelize.Options{RawCellValue: true})
+}
+
+// openWorkbook opens the sheet selected by opts in the workbook at
+// filePath. Progress is estimated from the row reached against the sheet
+// dimension, scaled to the file size.
+func (r *Reader) openWorkbook(filePath string, size int64, opts Options) (*Stream, error) {
+	workbook, err := excelize.OpenFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet, err := selectSheet(workbook.GetSheetList(), opts.Sheet)
+	if err != nil {
+		workbook.Close()
+		return nil, err
+	}
+
+	rows, err := workbook.Rows(sheet)
+	if err != nil {
+		workbook.Close()
+		return nil, err
+	}
+
+	totalRows := 0
+	if dimension, err := workbook.GetSheetDimension(sheet); err == nil {
+		totalRows = lastRow(dimension)
+	}
+
+	source := &workbookRows{rows: rows}
+	offset := func() int64 {
+		switch {
+		case totalRows == 0:
+			return 0
+		case source.row >= totalRows:
+			return size
+		default:
+			return size * int64(source.row) / int64(totalRows)
+		}
+	}
+
+	return r.open(source, offset, size, opts, rows, workbook)
+}
+
+// selectSheet returns the sheet named sheet, or the sheet at that 1-based
+// position. The first sheet is used when sheet is empty.
+func selectSheet(sheets []string, sheet string) (string, error) {
+	if len(sheets) == 0 {
+		return "", fmt.Errorf("workbook has no sheets")
+	}
+	if sheet == "" {
+		return sheets[0], nil
+	}
+
+	for _, name := range sheets {
+		if name == sheet {
+			return name, nil
+		}
+	}
+	if index, err := strconv.Atoi(sheet); err == nil && index >= 1 && index <= len(sheets) {
+		return sheets[index-1], nil
+	}
+	return "", fmt.Errorf("sheet %q not found", sheet)
+}
+
+// lastRow returns the last row of a dimension such as "A1:M120", or zero
+// when it cannot be parsed.
+func lastRow(dimension string) int {
+	end := dimension[strings.LastIndex(dimension, ":")+1:]
+	_, row, err := excelize.CellNameToCoordinates(end)
+	if err != nil {
+		return 0
+	}
+	return row
+}
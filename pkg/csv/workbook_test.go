@@ -0,0 +1,101 @@
+// ============================================
+// pkg/csv/workbook_test.go
+// ============================================
+package csv
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+// writeTestWorkbook writes a workbook whose "Products" sheet has a title
+// row above the header, after an unrelated first sheet.
+func writeTestWorkbook(t *testing.T) string {
+	workbook := excelize.NewFile()
+	defer workbook.Close()
+
+	require.NoError(t, workbook.SetSheetName("Sheet1", "Notes"))
+	require.NoError(t, workbook.SetCellValue("Notes", "A1", "Exported from ERP"))
+
+	_, err := workbook.NewSheet("Products")
+	require.NoError(t, err)
+	rows := [][]interface{}{
+		{"Spring catalog"},
+		{"Id", "Name", "Price", "Stock", "Internal ID", "Currency"},
+		{1, "Fan", 10.5, 5, 7, "USD"},
+		{},
+		{2, "Mouse", 20, 6, 8, "EUR"},
+	}
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		require.NoError(t, err)
+		require.NoError(t, workbook.SetSheetRow("Products", cell, &row))
+	}
+
+	// Formatted prices are read as their raw value
+	style, err := workbook.NewStyle(&excelize.Style{NumFmt: 8})
+	require.NoError(t, err)
+	require.NoError(t, workbook.SetCellStyle("Products", "C3", "C5", style))
+
+	filePath := filepath.Join(t.TempDir(), "products.xlsx")
+	require.NoError(t, workbook.SaveAs(filePath))
+	return filePath
+}
+
+func TestReader_OpenWorkbook(t *testing.T) {
+	for _, sheet := range []string{"Products", "2"} {
+		t.Run("success - sheet "+sheet, func(t *testing.T) {
+			filePath := writeTestWorkbook(t)
+
+			stream, err := NewReader(nil).Open(filePath, Options{Sheet: sheet, HeaderRow: 2})
+			require.NoError(t, err)
+			defer stream.Close()
+
+			record, err := stream.Next()
+			require.NoError(t, err)
+			assert.Equal(t, "1", record.ID)
+			assert.Equal(t, "Fan", record.Name)
+			assert.Equal(t, "10.5", record.Price)
+			assert.Equal(t, "USD", record.Currency)
+			assert.Equal(t, 3, record.RowNumber)
+
+			// The empty row is skipped
+			record, err = stream.Next()
+			require.NoError(t, err)
+			assert.Equal(t, "Mouse", record.Name)
+			assert.Equal(t, 5, record.RowNumber)
+
+			_, err = stream.Next()
+			assert.Equal(t, io.EOF, err)
+			assert.Equal(t, stream.Size(), stream.Offset())
+		})
+	}
+
+	t.Run("error - header not found on first sheet", func(t *testing.T) {
+		stream, err := NewReader(nil).Open(writeTestWorkbook(t), Options{})
+
+		assert.Error(t, err)
+		assert.Nil(t, stream)
+		assert.Contains(t, err.Error(), "missing required column(s)")
+	})
+
+	t.Run("error - sheet not found", func(t *testing.T) {
+		stream, err := NewReader(nil).Open(writeTestWorkbook(t), Options{Sheet: "3"})
+
+		assert.Error(t, err)
+		assert.Nil(t, stream)
+		assert.Contains(t, err.Error(), `sheet "3" not found`)
+	})
+
+	t.Run("success - not listed as an archive", func(t *testing.T) {
+		entries, err := ArchiveEntries(writeTestWorkbook(t))
+
+		assert.NoError(t, err)
+		assert.Nil(t, entries)
+	})
+}
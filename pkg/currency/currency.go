@@ -0,0 +1,40 @@
+// ============================================
+// pkg/currency/currency.go
+// ============================================
+package currency
+
+import (
+	"strings"
+)
+
+// codes holds the active ISO 4217 currency codes. Precious metals, test
+// codes and other units that are not money are left out.
+var codes = map[string]struct{}{}
+
+func init() {
+	for _, code := range strings.Fields(`
+		AED AFN ALL AMD AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB
+		BOV BRL BSD BTN BWP BYN BZD CAD CDF CHE CHF CHW CLF CLP CNY COP COU CRC
+		CUP CVE CZK DJF DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD
+		GNF GTQ GYD HKD HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES KGS
+		KHR KMF KPW KRW KWD KYD KZT LAK LBP LKR LRD LSL LYD MAD MDL MGA MKD MMK
+		MNT MOP MRU MUR MVR MWK MXN MXV MYR MZN NAD NGN NIO NOK NPR NZD OMR PAB
+		PEN PGK PHP PKR PLN PYG QAR RON RSD RUB RWF SAR SBD SCR SDG SEK SGD SHP
+		SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS UAH
+		UGX USD USN UYI UYU UYW UZS VED VES VND VUV WST XAF XCD XCG XOF XPF YER
+		ZAR ZMW ZWG`) {
+		codes[code] = struct{}{}
+	}
+}
+
+// Valid reports whether code is an active ISO 4217 currency code, which
+// is written in upper case.
+func Valid(code string) bool {
+	_, ok := codes[code]
+	return ok
+}
+
+// Normalize trims code and writes it in upper case.
+func Normalize(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
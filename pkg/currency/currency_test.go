@@ -0,0 +1,26 @@
+// ============================================
+// pkg/currency/currency_test.go
+// ============================================
+package currency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValid(t *testing.T) {
+	for _, code := range []string{"USD", "EUR", "JPY", "CHF", "XOF"} {
+		assert.True(t, Valid(code), code)
+	}
+
+	// Lower case, withdrawn, metals and free text
+	for _, code := range []string{"usd", "DEM", "HRK", "XAU", "XXX", "US Dollar", ""} {
+		assert.False(t, Valid(code), code)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "EUR", Normalize(" eur "))
+	assert.Equal(t, "", Normalize("  "))
+}
@@ -0,0 +1,118 @@
+// ============================================
+// pkg/gtin/gtin.go
+// ============================================
+package gtin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Strictness says what happens to a row whose code is invalid.
+type Strictness string
+
+const (
+	// StrictnessOff stores codes verbatim without checking them
+	StrictnessOff Strictness = "off"
+	// StrictnessFlag keeps invalid codes verbatim and reports them
+	StrictnessFlag Strictness = "flag"
+	// StrictnessReject fails rows with invalid codes
+	StrictnessReject Strictness = "reject"
+)
+
+// Format is the form valid codes are normalised to.
+type Format string
+
+const (
+	FormatEAN13  Format = "ean13"
+	FormatGTIN14 Format = "gtin14"
+)
+
+var (
+	ErrInvalidCharacters = errors.New("must only contain digits")
+	ErrInvalidLength     = errors.New("must have 8 to 14 digits")
+	ErrCheckDigit        = errors.New("invalid check digit")
+	// ErrNotEAN13 is returned for a GTIN-14 with a packaging indicator,
+	// which has no EAN-13 form
+	ErrNotEAN13 = errors.New("GTIN-14 with packaging indicator cannot be written as EAN-13")
+)
+
+// Policy says how product codes are checked and normalised.
+type Policy struct {
+	Strictness Strictness
+	Format     Format
+}
+
+// Enabled reports whether codes are checked; the zero policy is off.
+func (p Policy) Enabled() bool {
+	return p.Strictness == StrictnessFlag || p.Strictness == StrictnessReject
+}
+
+// ParsePolicy parses a strictness and a format, which default to off and
+// EAN-13.
+func ParsePolicy(strictness, format string) (Policy, error) {
+	policy := Policy{Strictness: StrictnessOff, Format: FormatEAN13}
+
+	switch s := Strictness(strings.ToLower(strictness)); s {
+	case "":
+	case StrictnessOff, StrictnessFlag, StrictnessReject:
+		policy.Strictness = s
+	default:
+		return Policy{}, fmt.Errorf("unknown strictness %q", strictness)
+	}
+
+	switch f := Format(strings.ToLower(format)); f {
+	case "":
+	case FormatEAN13, FormatGTIN14:
+		policy.Format = f
+	default:
+		return Policy{}, fmt.Errorf("unknown format %q", format)
+	}
+
+	return policy, nil
+}
+
+// Normalize checks an EAN-8, UPC-A, EAN-13 or GTIN-14 code and returns it
+// in format. Spaces and hyphens are removed, and codes of 9 to 11 digits
+// are taken to have lost leading zeros, as happens when a spreadsheet
+// reads them as numbers.
+func Normalize(code string, format Format) (string, error) {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(code)
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", ErrInvalidCharacters
+		}
+	}
+	if len(digits) < 8 || len(digits) > 14 {
+		return "", ErrInvalidLength
+	}
+
+	// Leading zeros do not change the check digit
+	gtin14 := strings.Repeat("0", 14-len(digits)) + digits
+	if CheckDigit(gtin14[:13]) != gtin14[13] {
+		return "", ErrCheckDigit
+	}
+
+	if format == FormatGTIN14 {
+		return gtin14, nil
+	}
+	if gtin14[0] != '0' {
+		return "", ErrNotEAN13
+	}
+	return gtin14[1:], nil
+}
+
+// CheckDigit returns the GS1 check digit for the digits preceding it.
+func CheckDigit(digits string) byte {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := int(digits[i] - '0')
+		// Weights alternate 3, 1, 3, ... from the right
+		if (len(digits)-1-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return byte('0' + (10-sum%10)%10)
+}
@@ -0,0 +1,86 @@
+// ============================================
+// pkg/gtin/gtin_test.go
+// ============================================
+package gtin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		for _, tc := range []struct {
+			name   string
+			code   string
+			format Format
+			want   string
+		}{
+			{"ean-13", "4006381333931", FormatEAN13, "4006381333931"},
+			{"ean-13 to gtin-14", "4006381333931", FormatGTIN14, "04006381333931"},
+			{"ean-8", "96385074", FormatEAN13, "0000096385074"},
+			{"upc-a", "036000291452", FormatEAN13, "0036000291452"},
+			{"gtin-14", "10036000291459", FormatGTIN14, "10036000291459"},
+			{"lost leading zeros", "36000291452", FormatEAN13, "0036000291452"},
+			{"separators", "400-6381 333931", FormatEAN13, "4006381333931"},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := Normalize(tc.code, tc.format)
+
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			})
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		for _, tc := range []struct {
+			name   string
+			code   string
+			format Format
+			want   error
+		}{
+			{"check digit", "4006381333932", FormatEAN13, ErrCheckDigit},
+			{"letters", "40063813339AB", FormatEAN13, ErrInvalidCharacters},
+			{"too short", "1234567", FormatEAN13, ErrInvalidLength},
+			{"too long", "123456789012345", FormatGTIN14, ErrInvalidLength},
+			{"scientific notation", "4.00638E+12", FormatEAN13, ErrInvalidCharacters},
+			{"packaging indicator", "10036000291459", FormatEAN13, ErrNotEAN13},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := Normalize(tc.code, tc.format)
+
+				assert.ErrorIs(t, err, tc.want)
+			})
+		}
+	})
+}
+
+func TestParsePolicy(t *testing.T) {
+	t.Run("success - defaults", func(t *testing.T) {
+		policy, err := ParsePolicy("", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, Policy{Strictness: StrictnessOff, Format: FormatEAN13}, policy)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		policy, err := ParsePolicy("Reject", "gtin14")
+
+		assert.NoError(t, err)
+		assert.Equal(t, Policy{Strictness: StrictnessReject, Format: FormatGTIN14}, policy)
+	})
+
+	t.Run("error - unknown strictness", func(t *testing.T) {
+		_, err := ParsePolicy("strict", "")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("error - unknown format", func(t *testing.T) {
+		_, err := ParsePolicy("flag", "upc")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,142 @@
+// ============================================
+// pkg/sandbox/expand.go
+// ============================================
+package sandbox
+
+import (
+	"data-processing/internal/domain"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Expand turns a request path into the import paths it names, sorted by
+// name. A directory yields the files in it, or below it when recursive is
+// set, and a glob pattern yields its matches. A path that exists is never
+// treated as a pattern, and a plain file that does not exist is returned
+// as is so the import reports it. Hidden entries are skipped.
+func (r *Root) Expand(path string, recursive bool) ([]string, error) {
+	if _, err := r.Resolve(path); err != nil {
+		return nil, err
+	}
+	rel := filepath.Clean(filepath.FromSlash(strings.TrimLeft(path, "/")))
+
+	info, err := os.Stat(filepath.Join(r.dir, rel))
+	switch {
+	case err == nil && info.IsDir():
+		return r.expandDir(path, rel, recursive)
+	case err == nil || !hasMeta(rel):
+		return []string{importPath(rel)}, nil
+	}
+
+	matches, err := filepath.Glob(filepath.Join(r.dir, rel))
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %s: %w", path, err)
+	}
+
+	var paths []string
+	for _, match := range matches {
+		matchRel, err := filepath.Rel(r.dir, match)
+		if err != nil {
+			return nil, err
+		}
+		if isHidden(filepath.Base(matchRel)) {
+			continue
+		}
+
+		found, err := r.collect(matchRel, recursive)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, found...)
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%w: %s", domain.ErrNoMatchingFiles, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func (r *Root) expandDir(path, rel string, recursive bool) ([]string, error) {
+	paths, err := r.collect(rel, recursive)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%w: %s", domain.ErrNoMatchingFiles, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// collect returns rel if it is a file, or the files in it if it is a
+// directory. Every entry is checked against the root, and symlinked
+// directories are not followed to avoid loops.
+func (r *Root) collect(rel string, recursive bool) ([]string, error) {
+	real, err := r.Resolve(rel)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(real)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{importPath(rel)}, nil
+	}
+
+	var paths []string
+	err = filepath.WalkDir(filepath.Join(r.dir, rel), func(full string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		entryRel, err := filepath.Rel(r.dir, full)
+		if err != nil {
+			return err
+		}
+		if entryRel == rel {
+			return nil
+		}
+		if entry.IsDir() {
+			if !recursive || isHidden(entry.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if isHidden(entry.Name()) {
+			return nil
+		}
+
+		real, err := r.Resolve(entryRel)
+		if err != nil {
+			return err
+		}
+		if info, err := os.Stat(real); err != nil || !info.Mode().IsRegular() {
+			return nil
+		}
+		paths = append(paths, importPath(entryRel))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// importPath formats rel the way import requests name files.
+func importPath(rel string) string {
+	return "/" + filepath.ToSlash(rel)
+}
+
+func hasMeta(path string) bool {
+	return strings.ContainsAny(path, `*?[`)
+}
+
+func isHidden(name string) bool {
+	return strings.HasPrefix(name, ".")
+}
@@ -0,0 +1,129 @@
+// ============================================
+// pkg/sandbox/expand_test.go
+// ============================================
+package sandbox
+
+import (
+	"data-processing/internal/domain"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDropFolder creates an import root with a typical drop folder layout.
+func newDropFolder(t *testing.T) *Root {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"csv/b.csv",
+		"csv/a.csv",
+		"csv/notes.txt",
+		"csv/.partial.csv",
+		"csv/2026-10-17/c.csv",
+		"csv/2026-10-17/nested/d.csv",
+		"csv/.archive/old.csv",
+		"csv/[draft].csv",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, nil, 0o644))
+	}
+
+	root, err := NewRoot(dir)
+	require.NoError(t, err)
+	return root
+}
+
+func TestRoot_Expand(t *testing.T) {
+	t.Run("success - plain file", func(t *testing.T) {
+		root := newDropFolder(t)
+
+		paths, err := root.Expand("csv/a.csv", false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/csv/a.csv"}, paths)
+	})
+
+	t.Run("success - missing file is kept", func(t *testing.T) {
+		root := newDropFolder(t)
+
+		paths, err := root.Expand("/csv/missing.csv", false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/csv/missing.csv"}, paths)
+	})
+
+	t.Run("success - existing name is not a pattern", func(t *testing.T) {
+		root := newDropFolder(t)
+
+		paths, err := root.Expand("/csv/[draft].csv", false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/csv/[draft].csv"}, paths)
+	})
+
+	t.Run("success - directory", func(t *testing.T) {
+		root := newDropFolder(t)
+
+		paths, err := root.Expand("/csv/", false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/csv/[draft].csv", "/csv/a.csv", "/csv/b.csv", "/csv/notes.txt"}, paths)
+	})
+
+	t.Run("success - directory recursive", func(t *testing.T) {
+		root := newDropFolder(t)
+
+		paths, err := root.Expand("/csv/2026-10-17", true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/csv/2026-10-17/c.csv", "/csv/2026-10-17/nested/d.csv"}, paths)
+	})
+
+	t.Run("success - glob", func(t *testing.T) {
+		root := newDropFolder(t)
+
+		paths, err := root.Expand("/csv/*.csv", false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/csv/[draft].csv", "/csv/a.csv", "/csv/b.csv"}, paths)
+	})
+
+	t.Run("success - glob matching directories", func(t *testing.T) {
+		root := newDropFolder(t)
+
+		paths, err := root.Expand("/csv/2026-*", true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/csv/2026-10-17/c.csv", "/csv/2026-10-17/nested/d.csv"}, paths)
+	})
+
+	t.Run("error - glob matches nothing", func(t *testing.T) {
+		root := newDropFolder(t)
+
+		_, err := root.Expand("/csv/*.xlsx", false)
+
+		assert.ErrorIs(t, err, domain.ErrNoMatchingFiles)
+	})
+
+	t.Run("error - glob outside root", func(t *testing.T) {
+		root := newDropFolder(t)
+
+		_, err := root.Expand("/../*", false)
+
+		assert.ErrorIs(t, err, domain.ErrPathNotAllowed)
+	})
+
+	t.Run("error - symlink outside root", func(t *testing.T) {
+		root := newDropFolder(t)
+		outside := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(outside, "secret.csv"), nil, 0o644))
+		require.NoError(t, os.Symlink(filepath.Join(outside, "secret.csv"), filepath.Join(root.Dir(), "csv", "secret.csv")))
+
+		_, err := root.Expand("/csv/", false)
+
+		assert.ErrorIs(t, err, domain.ErrPathNotAllowed)
+	})
+}
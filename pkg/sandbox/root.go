@@ -0,0 +1,83 @@
+// ============================================
+// pkg/sandbox/root.go
+// ============================================
+package sandbox
+
+import (
+	"data-processing/internal/domain"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// Root resolves import paths inside a single directory. Request paths are
+// relative to the root whether or not they start with a slash.
+type Root struct {
+	dir string
+}
+
+// NewRoot canonicalises dir, following symlinks, so later checks compare
+// real paths.
+func NewRoot(dir string) (*Root, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	real, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		return nil, err
+	}
+	return &Root{dir: real}, nil
+}
+
+// Dir returns the canonical root directory.
+func (r *Root) Dir() string {
+	return r.dir
+}
+
+// Resolve returns the absolute path of path inside the root. Paths that
+// climb out of the root with "..", or through a symlink pointing outside
+// it, are rejected with domain.ErrPathNotAllowed.
+func (r *Root) Resolve(path string) (string, error) {
+	if strings.ContainsRune(path, 0) {
+		return "", fmt.Errorf("%w: %q", domain.ErrPathNotAllowed, path)
+	}
+
+	rel := filepath.Clean(filepath.FromSlash(strings.TrimLeft(path, "/")))
+	if !filepath.IsLocal(rel) && rel != "." {
+		return "", fmt.Errorf("%w: %s", domain.ErrPathNotAllowed, path)
+	}
+	full := filepath.Join(r.dir, rel)
+
+	real, err := r.realPath(full)
+	if err != nil {
+		return "", err
+	}
+	if !r.contains(real) {
+		return "", fmt.Errorf("%w: %s", domain.ErrPathNotAllowed, path)
+	}
+	return real, nil
+}
+
+// realPath follows symlinks in path. A path that does not exist yet is
+// resolved through its deepest existing parent, so a missing file under a
+// symlinked directory is still checked. path must be lexically inside the
+// root, which always exists.
+func (r *Root) realPath(path string) (string, error) {
+	real, err := filepath.EvalSymlinks(path)
+	if errors.Is(err, fs.ErrNotExist) && path != r.dir {
+		parent, err := r.realPath(filepath.Dir(path))
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(parent, filepath.Base(path)), nil
+	}
+	return real, err
+}
+
+func (r *Root) contains(path string) bool {
+	rel, err := filepath.Rel(r.dir, path)
+	return err == nil && (rel == "." || filepath.IsLocal(rel))
+}
@@ -0,0 +1,96 @@
+// ============================================
+// pkg/sandbox/root_test.go
+// ============================================
+package sandbox
+
+import (
+	"data-processing/internal/domain"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRoot(t *testing.T) (*Root, string) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "csv"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "csv", "products.csv"), nil, 0o644))
+
+	root, err := NewRoot(dir)
+	require.NoError(t, err)
+	return root, root.Dir()
+}
+
+func TestRoot_Resolve(t *testing.T) {
+	t.Run("success - leading slash is optional", func(t *testing.T) {
+		root, dir := newTestRoot(t)
+		expected := filepath.Join(dir, "csv", "products.csv")
+
+		for _, path := range []string{"/csv/products.csv", "csv/products.csv", "/csv/./products.csv"} {
+			resolved, err := root.Resolve(path)
+
+			assert.NoError(t, err, path)
+			assert.Equal(t, expected, resolved, path)
+		}
+	})
+
+	t.Run("success - missing file inside root", func(t *testing.T) {
+		root, dir := newTestRoot(t)
+
+		resolved, err := root.Resolve("/csv/missing.csv")
+
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "csv", "missing.csv"), resolved)
+	})
+
+	t.Run("success - symlink inside root", func(t *testing.T) {
+		root, dir := newTestRoot(t)
+		require.NoError(t, os.Symlink(filepath.Join(dir, "csv"), filepath.Join(dir, "latest")))
+
+		resolved, err := root.Resolve("/latest/products.csv")
+
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "csv", "products.csv"), resolved)
+	})
+
+	t.Run("error - escapes with dot-dot", func(t *testing.T) {
+		root, _ := newTestRoot(t)
+
+		for _, path := range []string{"/../../etc/passwd", "../secret.csv", "/csv/../../secret.csv"} {
+			_, err := root.Resolve(path)
+
+			assert.ErrorIs(t, err, domain.ErrPathNotAllowed, path)
+		}
+	})
+
+	t.Run("error - symlink pointing outside root", func(t *testing.T) {
+		root, dir := newTestRoot(t)
+		outside := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(outside, "secret.csv"), nil, 0o644))
+		require.NoError(t, os.Symlink(outside, filepath.Join(dir, "escape")))
+
+		_, err := root.Resolve("/escape/secret.csv")
+		assert.ErrorIs(t, err, domain.ErrPathNotAllowed)
+
+		_, err = root.Resolve("/escape/missing.csv")
+		assert.ErrorIs(t, err, domain.ErrPathNotAllowed)
+	})
+
+	t.Run("error - nul byte", func(t *testing.T) {
+		root, _ := newTestRoot(t)
+
+		_, err := root.Resolve("/csv/products.csv\x00.txt")
+
+		assert.ErrorIs(t, err, domain.ErrPathNotAllowed)
+	})
+}
+
+func TestNewRoot(t *testing.T) {
+	t.Run("error - missing directory", func(t *testing.T) {
+		_, err := NewRoot(filepath.Join(t.TempDir(), "missing"))
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,96 @@
+// ============================================
+// pkg/upload/store.go
+// ============================================
+package upload
+
+import (
+	"crypto/sha256"
+	"data-processing/internal/domain"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Store keeps uploaded files under dir, one sub-directory per upload so
+// files with the same name never overwrite each other.
+type Store struct {
+	root    string
+	dir     string
+	maxSize int64
+}
+
+// NewStore creates a store in dir inside the import root, so stored files
+// are returned as import paths relative to root. Files larger than maxSize
+// bytes are rejected.
+func NewStore(root, dir string, maxSize int64) (domain.FileStore, error) {
+	dir = filepath.Clean(strings.TrimLeft(filepath.FromSlash(dir), "/"))
+	if !filepath.IsLocal(dir) {
+		return nil, fmt.Errorf("upload directory %s is outside %s", dir, root)
+	}
+	if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+		return nil, fmt.Errorf("create upload directory: %w", err)
+	}
+	return &Store{root: root, dir: dir, maxSize: maxSize}, nil
+}
+
+// Save streams r to disk while computing its SHA-256 checksum.
+func (s *Store) Save(name string, r io.Reader) (*domain.StoredFile, error) {
+	name = sanitizeName(name)
+	relDir := filepath.Join(s.dir, uuid.NewString())
+	dir := filepath.Join(s.root, relDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(filepath.Join(dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	hash := sha256.New()
+	// Read one byte past the limit to tell a file of exactly maxSize
+	// bytes from a larger one
+	size, err := io.Copy(io.MultiWriter(file, hash), io.LimitReader(r, s.maxSize+1))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && size > s.maxSize {
+		err = fmt.Errorf("%w: %s exceeds %d bytes", domain.ErrFileTooLarge, name, s.maxSize)
+	}
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	return &domain.StoredFile{
+		Name:   name,
+		Path:   "/" + filepath.ToSlash(filepath.Join(relDir, name)),
+		Size:   size,
+		SHA256: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// Remove deletes a stored file together with its upload directory.
+func (s *Store) Remove(file *domain.StoredFile) error {
+	dir := filepath.Dir(filepath.FromSlash(strings.TrimPrefix(file.Path, "/")))
+	if filepath.Dir(dir) != s.dir {
+		return fmt.Errorf("%s is not a stored upload", file.Path)
+	}
+	return os.RemoveAll(filepath.Join(s.root, dir))
+}
+
+// sanitizeName keeps only the base name of an uploaded file, as clients
+// may send full or malicious paths.
+func sanitizeName(name string) string {
+	name = filepath.Base(filepath.Clean("/" + strings.ReplaceAll(name, "\\", "/")))
+	if name == "/" || name == "." || name == "" {
+		return "upload.csv"
+	}
+	return name
+}
@@ -0,0 +1,121 @@
+// ============================================
+// pkg/upload/store_test.go
+// ============================================
+package upload
+
+import (
+	"data-processing/internal/domain"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(t *testing.T, maxSize int64) domain.FileStore {
+	root := t.TempDir()
+	t.Chdir(root)
+
+	store, err := NewStore(root, "uploads", maxSize)
+	require.NoError(t, err)
+	return store
+}
+
+func TestStore_Save(t *testing.T) {
+	t.Run("success - stores file with checksum", func(t *testing.T) {
+		store := newTestStore(t, 1024)
+
+		file, err := store.Save("products.csv", strings.NewReader("hello"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "products.csv", file.Name)
+		assert.Equal(t, int64(5), file.Size)
+		assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", file.SHA256)
+		assert.True(t, strings.HasPrefix(file.Path, "/uploads/"))
+
+		content, err := os.ReadFile(strings.TrimPrefix(file.Path, "/"))
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(content))
+	})
+
+	t.Run("success - same name does not overwrite", func(t *testing.T) {
+		store := newTestStore(t, 1024)
+
+		first, err := store.Save("products.csv", strings.NewReader("a"))
+		require.NoError(t, err)
+		second, err := store.Save("products.csv", strings.NewReader("b"))
+		require.NoError(t, err)
+
+		assert.NotEqual(t, first.Path, second.Path)
+	})
+
+	t.Run("success - strips directories from name", func(t *testing.T) {
+		store := newTestStore(t, 1024)
+
+		file, err := store.Save("../../etc/passwd", strings.NewReader("x"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "passwd", file.Name)
+		assert.True(t, strings.HasPrefix(file.Path, "/uploads/"))
+	})
+
+	t.Run("success - file of exactly the limit", func(t *testing.T) {
+		store := newTestStore(t, 5)
+
+		file, err := store.Save("products.csv", strings.NewReader("hello"))
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), file.Size)
+	})
+
+	t.Run("error - file too large", func(t *testing.T) {
+		store := newTestStore(t, 4)
+
+		file, err := store.Save("products.csv", strings.NewReader("hello"))
+
+		assert.ErrorIs(t, err, domain.ErrFileTooLarge)
+		assert.Nil(t, file)
+
+		entries, err := os.ReadDir("uploads")
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestStore_Remove(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		store := newTestStore(t, 1024)
+
+		file, err := store.Save("products.csv", strings.NewReader("hello"))
+		require.NoError(t, err)
+
+		assert.NoError(t, store.Remove(file))
+		_, err = os.Stat(filepath.Dir(strings.TrimPrefix(file.Path, "/")))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("error - outside the store", func(t *testing.T) {
+		store := newTestStore(t, 1024)
+
+		err := store.Remove(&domain.StoredFile{Path: "/csv/products.csv"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestNewStore(t *testing.T) {
+	t.Run("error - outside the root", func(t *testing.T) {
+		_, err := NewStore(t.TempDir(), "../uploads", 1024)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestSanitizeName(t *testing.T) {
+	assert.Equal(t, "products.csv", sanitizeName("products.csv"))
+	assert.Equal(t, "products.csv", sanitizeName(`C:\feeds\products.csv`))
+	assert.Equal(t, "upload.csv", sanitizeName(""))
+	assert.Equal(t, "upload.csv", sanitizeName("../"))
+}
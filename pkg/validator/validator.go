@@ -0,0 +1,334 @@
+// ============================================
+// pkg/validator/validator.go
+// ============================================
+package validator
+
+import (
+	"data-processing/internal/domain"
+	"data-processing/pkg/currency"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type kind int
+
+const (
+	text kind = iota
+	integer
+	decimal
+	currencyCode
+	availabilityValue
+)
+
+func (k kind) numeric() bool {
+	return k == integer || k == decimal
+}
+
+// column describes a products table column, whose limits rules cannot
+// relax.
+type column struct {
+	kind      kind
+	required  bool
+	maxLength int
+	min       float64
+	max       float64
+}
+
+// MaxPrice is the largest price the products table holds, DECIMAL(10, 2).
+const MaxPrice = 99999999.99
+
+// availabilities lists the availability values for messages.
+var availabilities = func() string {
+	names := make([]string, len(domain.Availabilities))
+	for i, availability := range domain.Availabilities {
+		names[i] = string(availability)
+	}
+	return strings.Join(names, ", ")
+}()
+
+// fields lists the product fields in the order violations are reported.
+var fields = []string{
+	"id", "name", "description", "brand", "category", "price", "currency",
+	"stock", "ean", "color", "size", "availability", "internal_id",
+}
+
+// columns mirrors the products table in migrations/000001_init_table.
+// Fields without which no product can be built are always required.
+var columns = map[string]column{
+	"id":           {kind: integer, required: true, min: math.MinInt32, max: math.MaxInt32},
+	"name":         {kind: text, required: true, maxLength: 100},
+	"description":  {kind: text},
+	"brand":        {kind: text, maxLength: 100},
+	"category":     {kind: text, maxLength: 100},
+	"price":        {kind: decimal, required: true, min: -MaxPrice, max: MaxPrice},
+	"currency":     {kind: currencyCode, maxLength: 20},
+	"stock":        {kind: integer, required: true, min: math.MinInt32, max: math.MaxInt32},
+	"ean":          {kind: text, maxLength: 50},
+	"color":        {kind: text, maxLength: 50},
+	"size":         {kind: text, maxLength: 50},
+	"availability": {kind: availabilityValue, maxLength: 50},
+	"internal_id":  {kind: integer, required: true, min: math.MinInt32, max: math.MaxInt32},
+}
+
+// DefaultRules returns the rules applied when a profile sets none: the
+// column limits, with prices and stock at least zero.
+func DefaultRules() domain.ValidationRules {
+	rules := make(domain.ValidationRules, len(columns))
+	for name, c := range columns {
+		rule := domain.FieldRule{Required: c.required, MaxLength: c.maxLength}
+		if c.kind.numeric() {
+			rule.Min, rule.Max = bound(c.min), bound(c.max)
+		}
+		rules[name] = rule
+	}
+
+	rules["price"] = withMin(rules["price"], 0)
+	rules["stock"] = withMin(rules["stock"], 0)
+	return rules
+}
+
+func withMin(rule domain.FieldRule, min float64) domain.FieldRule {
+	rule.Min = bound(min)
+	return rule
+}
+
+func bound(value float64) *float64 {
+	return &value
+}
+
+// Validator checks records against field rules before they are converted
+// into products.
+type Validator struct {
+	rules []fieldRule
+}
+
+type fieldRule struct {
+	field   string
+	kind    kind
+	rule    domain.FieldRule
+	pattern *regexp.Regexp
+}
+
+var defaultValidator = mustNew(nil)
+
+// Default returns the validator for the default rules.
+func Default() *Validator {
+	return defaultValidator
+}
+
+func mustNew(rules domain.ValidationRules) *Validator {
+	v, err := New(rules)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// New creates a validator for the default rules with rules replacing those
+// of the fields they name. A rule cannot make a required field optional,
+// allow longer values than its column holds or bound numbers beyond what
+// the column stores; unset bounds keep the default.
+func New(rules domain.ValidationRules) (*Validator, error) {
+	merged := DefaultRules()
+	for name, rule := range rules {
+		c, ok := columns[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q", name)
+		}
+		if err := merge(name, c, merged[name], &rule); err != nil {
+			return nil, err
+		}
+		merged[name] = rule
+	}
+
+	v := &Validator{rules: make([]fieldRule, 0, len(fields))}
+	for _, name := range fields {
+		compiled := fieldRule{field: name, kind: columns[name].kind, rule: merged[name]}
+		if pattern := compiled.rule.Pattern; pattern != "" {
+			var err error
+			compiled.pattern, err = regexp.Compile(`^(?:` + pattern + `)$`)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid pattern: %v", name, err)
+			}
+		}
+		v.rules = append(v.rules, compiled)
+	}
+	return v, nil
+}
+
+// merge completes rule from the default rule of its field and checks it
+// against the column.
+func merge(name string, c column, defaults domain.FieldRule, rule *domain.FieldRule) error {
+	rule.Required = rule.Required || c.required
+
+	switch {
+	case rule.MaxLength < 0:
+		return fmt.Errorf("%s: invalid max length %d", name, rule.MaxLength)
+	case rule.MaxLength == 0:
+		rule.MaxLength = defaults.MaxLength
+	case c.maxLength > 0 && rule.MaxLength > c.maxLength:
+		return fmt.Errorf("%s: max length %d exceeds the column size %d", name, rule.MaxLength, c.maxLength)
+	}
+
+	if c.kind == availabilityValue {
+		for _, allowed := range rule.Allowed {
+			if !domain.Availability(allowed).Valid() {
+				return fmt.Errorf("%s: unknown availability %q", name, allowed)
+			}
+		}
+	}
+
+	if !c.kind.numeric() {
+		if rule.Min != nil || rule.Max != nil {
+			return fmt.Errorf("%s: min and max only apply to numbers", name)
+		}
+		return nil
+	}
+
+	if rule.Min == nil {
+		rule.Min = defaults.Min
+	}
+	if rule.Max == nil {
+		rule.Max = defaults.Max
+	}
+	switch {
+	case *rule.Min < c.min:
+		return fmt.Errorf("%s: min %s is below the column limit %s", name, formatNumber(*rule.Min), formatNumber(c.min))
+	case *rule.Max > c.max:
+		return fmt.Errorf("%s: max %s exceeds the column limit %s", name, formatNumber(*rule.Max), formatNumber(c.max))
+	case *rule.Min > *rule.Max:
+		return fmt.Errorf("%s: min %s is greater than max %s", name, formatNumber(*rule.Min), formatNumber(*rule.Max))
+	}
+	return nil
+}
+
+// FieldError is a rule violated by the value of a field.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// Errors holds every rule a record violates.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Field + ": " + fieldErr.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Validate checks every field of record and returns all violations as
+// Errors, or nil when the record is valid.
+func (v *Validator) Validate(record *domain.CSVRecord) error {
+	var errs Errors
+	for _, rule := range v.rules {
+		if message := rule.check(value(record, rule.field)); message != "" {
+			errs = append(errs, FieldError{Field: rule.field, Message: message})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// check returns why value violates the rule, or an empty string.
+func (r fieldRule) check(value string) string {
+	if strings.TrimSpace(value) == "" {
+		if r.rule.Required {
+			return "is required"
+		}
+		return ""
+	}
+
+	switch r.kind {
+	case integer:
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return "must be a whole number"
+		}
+		if message := r.checkRange(float64(number)); message != "" {
+			return message
+		}
+	case decimal:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return "must be a number"
+		}
+		if message := r.checkRange(number); message != "" {
+			return message
+		}
+	case currencyCode:
+		if !currency.Valid(value) {
+			return "must be an ISO 4217 currency code"
+		}
+	case availabilityValue:
+		// Allowed, checked below, names a subset of the availabilities
+		if len(r.rule.Allowed) == 0 && !domain.Availability(value).Valid() {
+			return "must be one of " + availabilities
+		}
+	}
+
+	if r.rule.MaxLength > 0 && utf8.RuneCountInString(value) > r.rule.MaxLength {
+		return fmt.Sprintf("must be at most %d characters", r.rule.MaxLength)
+	}
+	if r.pattern != nil && !r.pattern.MatchString(value) {
+		return fmt.Sprintf("must match %s", r.rule.Pattern)
+	}
+	if len(r.rule.Allowed) > 0 && !slices.Contains(r.rule.Allowed, value) {
+		return fmt.Sprintf("must be one of %s", strings.Join(r.rule.Allowed, ", "))
+	}
+	return ""
+}
+
+func (r fieldRule) checkRange(number float64) string {
+	if r.rule.Min != nil && number < *r.rule.Min {
+		return "must be at least " + formatNumber(*r.rule.Min)
+	}
+	if r.rule.Max != nil && number > *r.rule.Max {
+		return "must be at most " + formatNumber(*r.rule.Max)
+	}
+	return ""
+}
+
+func formatNumber(number float64) string {
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
+
+func value(record *domain.CSVRecord, field string) string {
+	switch field {
+	case "id":
+		return record.ID
+	case "name":
+		return record.Name
+	case "description":
+		return record.Description
+	case "brand":
+		return record.Brand
+	case "category":
+		return record.Category
+	case "price":
+		return record.Price
+	case "currency":
+		return record.Currency
+	case "stock":
+		return record.Stock
+	case "ean":
+		return record.Ean
+	case "color":
+		return record.Color
+	case "size":
+		return record.Size
+	case "availability":
+		return record.Availability
+	default:
+		return record.InternalId
+	}
+}
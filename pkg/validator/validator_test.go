@@ -0,0 +1,145 @@
+// ============================================
+// pkg/validator/validator_test.go
+// ============================================
+package validator
+
+import (
+	"data-processing/internal/domain"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validRecord() *domain.CSVRecord {
+	return &domain.CSVRecord{
+		ID:           "1",
+		Name:         "Fan",
+		Price:        "10.50",
+		Currency:     "USD",
+		Stock:        "5",
+		Ean:          "4006381333931",
+		Availability: "in_stock",
+		InternalId:   "7",
+	}
+}
+
+func float64Ptr(value float64) *float64 {
+	return &value
+}
+
+func TestValidator_Validate(t *testing.T) {
+	t.Run("success - default rules", func(t *testing.T) {
+		assert.NoError(t, Default().Validate(validRecord()))
+	})
+
+	t.Run("error - default rules report every field", func(t *testing.T) {
+		record := validRecord()
+		record.ID = "1.5"
+		record.Name = " "
+		record.Price = "-1"
+		record.Stock = "many"
+		record.Brand = strings.Repeat("x", 101)
+
+		err := Default().Validate(record)
+
+		var errs Errors
+		require.ErrorAs(t, err, &errs)
+		assert.Equal(t, Errors{
+			{Field: "id", Message: "must be a whole number"},
+			{Field: "name", Message: "is required"},
+			{Field: "brand", Message: "must be at most 100 characters"},
+			{Field: "price", Message: "must be at least 0"},
+			{Field: "stock", Message: "must be a whole number"},
+		}, errs)
+	})
+
+	t.Run("error - price beyond the column", func(t *testing.T) {
+		record := validRecord()
+		record.Price = "100000000"
+
+		assert.EqualError(t, Default().Validate(record), "price: must be at most 99999999.99")
+	})
+
+	t.Run("error - unknown currency code", func(t *testing.T) {
+		record := validRecord()
+		record.Currency = "US Dollar"
+
+		assert.EqualError(t, Default().Validate(record), "currency: must be an ISO 4217 currency code")
+	})
+
+	t.Run("error - unknown availability", func(t *testing.T) {
+		record := validRecord()
+		record.Availability = "In Stock"
+
+		assert.EqualError(t, Default().Validate(record), "availability: must be one of "+
+			"in_stock, limited_stock, out_of_stock, backorder, pre_order, discontinued")
+	})
+
+	t.Run("success - configured rules", func(t *testing.T) {
+		v, err := New(domain.ValidationRules{
+			"ean":          {Required: true, Pattern: `\d{8}|\d{13}`},
+			"availability": {Allowed: []string{"in_stock", "out_of_stock"}},
+			"stock":        {Min: float64Ptr(-10), Max: float64Ptr(1000)},
+			"color":        {MaxLength: 10},
+		})
+		require.NoError(t, err)
+
+		record := validRecord()
+		record.Stock = "-3"
+		assert.NoError(t, v.Validate(record))
+
+		record.Ean = "4006381"
+		record.Availability = "maybe"
+		record.Stock = "1001"
+		record.Color = "Midnight blue"
+		assert.EqualError(t, v.Validate(record), "stock: must be at most 1000; "+
+			"ean: must match \\d{8}|\\d{13}; "+
+			"color: must be at most 10 characters; "+
+			"availability: must be one of in_stock, out_of_stock")
+
+		record = validRecord()
+		record.Ean = ""
+		assert.EqualError(t, v.Validate(record), "ean: is required")
+	})
+
+	t.Run("success - optional field left empty", func(t *testing.T) {
+		v, err := New(domain.ValidationRules{"currency": {Allowed: []string{"USD", "EUR"}}})
+		require.NoError(t, err)
+
+		record := validRecord()
+		record.Currency = ""
+		assert.NoError(t, v.Validate(record))
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Run("success - required fields stay required", func(t *testing.T) {
+		v, err := New(domain.ValidationRules{"name": {MaxLength: 50}})
+		require.NoError(t, err)
+
+		record := validRecord()
+		record.Name = ""
+		assert.EqualError(t, v.Validate(record), "name: is required")
+	})
+
+	for name, rules := range map[string]domain.ValidationRules{
+		"unknown field":                {"sku": {Required: true}},
+		"max length above column":      {"name": {MaxLength: 101}},
+		"negative max length":          {"name": {MaxLength: -1}},
+		"bounds on text":               {"color": {Min: float64Ptr(1)}},
+		"max above column":             {"price": {Max: float64Ptr(1e9)}},
+		"min above max":                {"stock": {Min: float64Ptr(10), Max: float64Ptr(5)}},
+		"min below column":             {"id": {Min: float64Ptr(-1e10)}},
+		"invalid regular expression":   {"ean": {Pattern: "[0-9"}},
+		"unknown allowed availability": {"availability": {Allowed: []string{"preorder"}}},
+	} {
+		t.Run("error - "+name, func(t *testing.T) {
+			v, err := New(rules)
+
+			assert.Error(t, err)
+			assert.Nil(t, v)
+		})
+	}
+}